@@ -37,6 +37,22 @@ var endpoints []func(*httprouter.Router, configuration.Config, Broker)
 
 type Broker interface {
 	Message(msg model.Message) error
+	ListSubscriptions() ([]model.Subscription, error)
+	GetSubscription(key string) (model.Subscription, error)
+	CreateSubscription(sub model.Subscription) error
+	SetSubscription(sub model.Subscription) error
+	DeleteSubscription(key string) error
+	ListDeadLetters() ([]model.Delivery, error)
+	GetDeadLetter(id string) (model.Delivery, error)
+	ReplayDeadLetter(id string) error
+	DeleteDeadLetter(id string) error
+	PurgeDeadLetters() error
+	ListSilences() ([]model.Silence, error)
+	GetSilence(id string) (model.Silence, error)
+	CreateSilence(silence model.Silence) (model.Silence, error)
+	SetSilence(silence model.Silence) error
+	DeleteSilence(id string) error
+	ListSilencedMessages() ([]model.SilencedMessage, error)
 }
 
 func Start(ctx context.Context, wg *sync.WaitGroup, config configuration.Config, broker Broker) error {
@@ -0,0 +1,81 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"github.com/julienschmidt/httprouter"
+)
+
+func init() {
+	endpoints = append(endpoints, DeadLettersEndpoint)
+}
+
+func DeadLettersEndpoint(router *httprouter.Router, config configuration.Config, broker Broker) {
+	router.GET("/dead-letters", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		result, err := broker.ListDeadLetters()
+		if err != nil {
+			config.GetLogger().Error("unable to list dead letters", "error", err)
+			http.Error(writer, err.Error(), getStatusCode(err))
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_ = json.NewEncoder(writer).Encode(result)
+	})
+
+	router.GET("/dead-letters/:id", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		result, err := broker.GetDeadLetter(params.ByName("id"))
+		if err != nil {
+			http.Error(writer, err.Error(), getStatusCode(err))
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_ = json.NewEncoder(writer).Encode(result)
+	})
+
+	router.POST("/dead-letters/:id/replay", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		err := broker.ReplayDeadLetter(params.ByName("id"))
+		if err != nil {
+			config.GetLogger().Error("unable to replay dead letter", "id", params.ByName("id"), "error", err)
+			http.Error(writer, err.Error(), getStatusCode(err))
+			return
+		}
+		writer.WriteHeader(http.StatusOK)
+	})
+
+	router.DELETE("/dead-letters/:id", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		err := broker.DeleteDeadLetter(params.ByName("id"))
+		if err != nil {
+			http.Error(writer, err.Error(), getStatusCode(err))
+			return
+		}
+		writer.WriteHeader(http.StatusOK)
+	})
+
+	router.DELETE("/dead-letters", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		err := broker.PurgeDeadLetters()
+		if err != nil {
+			config.GetLogger().Error("unable to purge dead letters", "error", err)
+			http.Error(writer, err.Error(), getStatusCode(err))
+			return
+		}
+		writer.WriteHeader(http.StatusOK)
+	})
+}
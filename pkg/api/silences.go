@@ -0,0 +1,112 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+	"github.com/julienschmidt/httprouter"
+)
+
+func init() {
+	endpoints = append(endpoints, SilencesEndpoint)
+}
+
+func SilencesEndpoint(router *httprouter.Router, config configuration.Config, broker Broker) {
+	router.GET("/silences", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		result, err := broker.ListSilences()
+		if err != nil {
+			config.GetLogger().Error("unable to list silences", "error", err)
+			http.Error(writer, err.Error(), getStatusCode(err))
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_ = json.NewEncoder(writer).Encode(result)
+	})
+
+	router.GET("/silences/:id", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		result, err := broker.GetSilence(params.ByName("id"))
+		if err != nil {
+			http.Error(writer, err.Error(), getStatusCode(err))
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_ = json.NewEncoder(writer).Encode(result)
+	})
+
+	//responds with the created silence, to inform the client about the generated id
+	router.POST("/silences", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		silence := model.Silence{}
+		err := json.NewDecoder(request.Body).Decode(&silence)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err := broker.CreateSilence(silence)
+		if err != nil {
+			http.Error(writer, err.Error(), getStatusCode(err))
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_ = json.NewEncoder(writer).Encode(result)
+	})
+
+	router.PUT("/silences/:id", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		silence := model.Silence{}
+		err := json.NewDecoder(request.Body).Decode(&silence)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if silence.Id == "" {
+			silence.Id = params.ByName("id")
+		}
+		if silence.Id != params.ByName("id") {
+			http.Error(writer, "silence id does not match path", http.StatusBadRequest)
+			return
+		}
+		err = broker.SetSilence(silence)
+		if err != nil {
+			http.Error(writer, err.Error(), getStatusCode(err))
+			return
+		}
+		writer.WriteHeader(http.StatusOK)
+	})
+
+	router.DELETE("/silences/:id", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		err := broker.DeleteSilence(params.ByName("id"))
+		if err != nil {
+			http.Error(writer, err.Error(), getStatusCode(err))
+			return
+		}
+		writer.WriteHeader(http.StatusOK)
+	})
+
+	router.GET("/silenced-messages", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		result, err := broker.ListSilencedMessages()
+		if err != nil {
+			config.GetLogger().Error("unable to list silenced messages", "error", err)
+			http.Error(writer, err.Error(), getStatusCode(err))
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_ = json.NewEncoder(writer).Encode(result)
+	})
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+	"github.com/julienschmidt/httprouter"
+)
+
+func init() {
+	endpoints = append(endpoints, SubscriptionsEndpoint)
+}
+
+func SubscriptionsEndpoint(router *httprouter.Router, config configuration.Config, broker Broker) {
+	router.GET("/subscriptions", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		result, err := broker.ListSubscriptions()
+		if err != nil {
+			config.GetLogger().Error("unable to list subscriptions", "error", err)
+			http.Error(writer, err.Error(), getStatusCode(err))
+			return
+		}
+		if result == nil {
+			result = []model.Subscription{}
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_ = json.NewEncoder(writer).Encode(result)
+	})
+
+	router.GET("/subscriptions/:key", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		result, err := broker.GetSubscription(params.ByName("key"))
+		if err != nil {
+			http.Error(writer, err.Error(), getStatusCode(err))
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_ = json.NewEncoder(writer).Encode(result)
+	})
+
+	router.POST("/subscriptions", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		sub := model.Subscription{}
+		err := json.NewDecoder(request.Body).Decode(&sub)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		err = broker.CreateSubscription(sub)
+		if err != nil {
+			http.Error(writer, err.Error(), getStatusCode(err))
+			return
+		}
+		writer.WriteHeader(http.StatusOK)
+	})
+
+	router.PUT("/subscriptions/:key", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		sub := model.Subscription{}
+		err := json.NewDecoder(request.Body).Decode(&sub)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if sub.Key == "" {
+			sub.Key = params.ByName("key")
+		}
+		if sub.Key != params.ByName("key") {
+			http.Error(writer, "subscription key does not match path", http.StatusBadRequest)
+			return
+		}
+		err = broker.SetSubscription(sub)
+		if err != nil {
+			http.Error(writer, err.Error(), getStatusCode(err))
+			return
+		}
+		writer.WriteHeader(http.StatusOK)
+	})
+
+	router.DELETE("/subscriptions/:key", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		err := broker.DeleteSubscription(params.ByName("key"))
+		if err != nil {
+			http.Error(writer, err.Error(), getStatusCode(err))
+			return
+		}
+		writer.WriteHeader(http.StatusOK)
+	})
+}
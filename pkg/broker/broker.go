@@ -19,38 +19,128 @@ package broker
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/delivery"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/distinct"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/kv"
 	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
 	"github.com/SENERGY-Platform/developer-notifications/pkg/receiver"
-	"github.com/patrickmn/go-cache"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/receiver/registry"
 )
 
 func New(ctx context.Context, wg *sync.WaitGroup, config configuration.Config) (broker *Broker, err error) {
+	config.GetLogger() //init logger before the config is copied into concurrently running components
 	receivers, err := receiver.New(ctx, wg, config)
 	if err != nil {
 		return nil, err
 	}
-	c := cache.New(5*time.Minute, 1*time.Minute)
+	distinctStore, err := distinct.New(ctx, wg, config)
+	if err != nil {
+		return nil, err
+	}
 
 	subscriptions, err := LoadSubscriptions(config)
 	if err != nil {
 		return nil, err
 	}
 
+	store, err := kv.New(config.DataDir, "subscriptions")
+	if err != nil {
+		return nil, err
+	}
+
+	deadLetters, err := kv.New(config.DataDir, "dead-letters")
+	if err != nil {
+		return nil, err
+	}
+
+	digests, err := kv.New(config.DataDir, "digests")
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := kv.New(config.DataDir, "groups")
+	if err != nil {
+		return nil, err
+	}
+
+	silences, err := kv.New(config.DataDir, "silences")
+	if err != nil {
+		return nil, err
+	}
+
+	silencedMessages, err := kv.New(config.DataDir, "silenced-messages")
+	if err != nil {
+		return nil, err
+	}
+
+	deferred, err := kv.New(config.DataDir, "deferred")
+	if err != nil {
+		return nil, err
+	}
+
 	broker = &Broker{
-		config:    config,
-		receivers: receivers,
-		cache:     c,
+		config:              config,
+		receivers:           receivers,
+		distinctStore:       distinctStore,
+		subscriptionStore:   store,
+		deadLetters:         deadLetters,
+		digests:             digests,
+		groups:              groups,
+		silenceStore:        silences,
+		silencedMessages:    silencedMessages,
+		deferred:            deferred,
+		staticSubscriptions: markReadOnly(subscriptions),
+	}
+
+	err = broker.updateSubscriptions()
+	if err != nil {
+		return nil, err
+	}
+
+	err = broker.updateSilences()
+	if err != nil {
+		return nil, err
+	}
+
+	err = broker.startSubscriptionReload(ctx, wg)
+	if err != nil {
+		return nil, err
+	}
+
+	err = broker.startSummaries(ctx, wg)
+	if err != nil {
+		return nil, err
+	}
+
+	err = broker.startSilenceCleanup(ctx, wg)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, sub := range subscriptions {
-		if _, ok := receivers.Get(sub.Receiver); !ok {
-			config.GetLogger().Warn("ignoring subscription to unknown or unconfigured receiver", "receiver", sub.Receiver, "subscription", sub.Key)
-		} else {
-			broker.subscriptions = append(broker.subscriptions, sub)
+	broker.dueCheckInterval, err = parseDueCheckInterval(config)
+	if err != nil {
+		return nil, err
+	}
+
+	err = broker.startDeferred(ctx, wg)
+	if err != nil {
+		return nil, err
+	}
+	broker.startDigests(ctx, wg)
+	broker.startGroups(ctx, wg)
+
+	if config.DeliveryQueue {
+		broker.queue, err = delivery.New(ctx, wg, config, receivers.Names(), func(d model.Delivery) error {
+			return broker.send(d.Message, d.Subscription)
+		}, broker.addDeadLetter)
+		if err != nil {
+			return nil, err
 		}
 	}
 
@@ -58,10 +148,29 @@ func New(ctx context.Context, wg *sync.WaitGroup, config configuration.Config) (
 }
 
 type Broker struct {
-	config        configuration.Config
-	receivers     *receiver.Receivers
-	subscriptions []model.Subscription
-	cache         *cache.Cache
+	config              configuration.Config
+	receivers           *receiver.Receivers
+	subscriptionStore   kv.Store
+	storeMux            sync.Mutex   //serializes changes to the subscriptionStore
+	subscriptionsMux    sync.RWMutex //guards staticSubscriptions and subscriptions; the slices are never modified, only replaced
+	staticSubscriptions []model.Subscription
+	subscriptions       []model.Subscription
+	distinctStore       distinct.Store
+	queue               *delivery.Queue //nil if config.DeliveryQueue is false
+	deadLetters         kv.Store
+	digests             kv.Store   //subscription key -> model.Digest
+	digestMux           sync.Mutex //serializes changes to the digests
+	dueCheckInterval    time.Duration
+	groups              kv.Store   //group key -> model.AlertGroup
+	groupMux            sync.Mutex //serializes changes to the groups
+	silenceStore        kv.Store   //id -> model.Silence
+	silenceMux          sync.Mutex //serializes changes to the silenceStore
+	silencesMux         sync.RWMutex
+	silences            []model.Silence //prepared silences, which have not expired; the slice is never modified, only replaced
+	silencedMessages    kv.Store        //id -> model.SilencedMessage
+	deferred            kv.Store        //id -> model.Delivery, messages received outside the active hours of their subscription
+	deferredMux         sync.Mutex      //serializes the release of deferred messages
+	deferredMaxAge      time.Duration   //0 keeps deferred messages until their subscription is active
 }
 
 func (this *Broker) Message(msg model.Message) error {
@@ -70,27 +179,122 @@ func (this *Broker) Message(msg model.Message) error {
 	errorList := []error{}
 	matches := []string{}
 	distinct := []string{}
-	for _, sub := range this.subscriptions {
+	inactive := []string{}
+	silenced := model.SilencedMessage{Time: time.Now(), Message: msg}
+	for _, sub := range this.getSubscriptions() {
 		if sub.Match(msg) {
 			matches = append(matches, sub.Key)
-			if this.IsDistinctMessage(msg, sub) {
-				distinct = append(distinct, sub.Key)
-				wg.Add(1)
-				go func(message model.Message, subscription model.Subscription) {
-					defer wg.Done()
-					err := this.send(message, subscription)
+			if silenceId, ok := this.getMatchingSilence(msg, sub.Key, silenced.Time); ok {
+				silenced.Subscriptions = append(silenced.Subscriptions, sub.Key)
+				if !slices.Contains(silenced.Silences, silenceId) {
+					silenced.Silences = append(silenced.Silences, silenceId)
+				}
+				continue
+			}
+			if !sub.IsActiveAt(silenced.Time) {
+				inactive = append(inactive, sub.Key)
+				if sub.DefersInactiveMessages() {
+					err := this.addDeferred(msg, sub, false)
 					if err != nil {
 						mux.Lock()
-						defer mux.Unlock()
 						errorList = append(errorList, err)
+						mux.Unlock()
 					}
-				}(msg, sub)
+				}
+				continue
 			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				isDistinct, err := this.handle(msg, sub)
+				mux.Lock()
+				defer mux.Unlock()
+				if isDistinct {
+					distinct = append(distinct, sub.Key)
+				}
+				if err != nil {
+					errorList = append(errorList, err)
+				}
+			}()
 		}
 	}
+	if len(silenced.Subscriptions) > 0 {
+		this.addSilencedMessage(silenced)
+	}
 	wg.Wait()
 	err := errors.Join(errorList...)
-	this.config.GetLogger().Debug("broker message", "error", err, "matches", matches, "sender", msg.Sender, "title", msg.Title, "tags", msg.Tags, "distinct", distinct)
+	this.config.GetLogger().Debug("broker message", "error", err, "matches", matches, "sender", msg.Sender, "title", msg.Title, "tags", msg.Tags, "severity", msg.GetSeverity(), "distinct", distinct, "silenced", silenced.Subscriptions, "inactive", inactive)
+	return err
+}
+
+// handle adds the message to the digest or group of the subscription or delivers it, if it is distinct
+func (this *Broker) handle(msg model.Message, sub model.Subscription) (isDistinct bool, err error) {
+	if sub.IsDigest() {
+		return false, this.addToDigest(msg, sub)
+	}
+	isDistinct, repetition := true, (*model.Repetition)(nil)
+	if sub.DistinctTimeWindow != "" || !sub.IsGrouped() {
+		isDistinct, repetition = this.IsDistinctMessage(msg, sub)
+	}
+	if !isDistinct {
+		return false, nil
+	}
+	msg.Repetition = repetition
+	if sub.IsGrouped() {
+		return true, this.addToGroup(msg, sub)
+	}
+	return true, this.deliver(msg, sub)
+}
+
+// DefaultDueCheckInterval is used if config.DueCheckInterval is not set
+const DefaultDueCheckInterval = time.Second
+
+func parseDueCheckInterval(config configuration.Config) (time.Duration, error) {
+	if config.DueCheckInterval == "" || config.DueCheckInterval == "-" {
+		return DefaultDueCheckInterval, nil
+	}
+	result, err := time.ParseDuration(config.DueCheckInterval)
+	if err != nil {
+		return 0, fmt.Errorf("invalid due_check_interval: %w", err)
+	}
+	if result <= 0 {
+		return 0, errors.New("invalid due_check_interval: must be positive")
+	}
+	return result, nil
+}
+
+// flush delivers a message created by a digest, group or summary, unless it is silenced;
+// outside the active hours of the subscription, the message is deferred or dropped like received messages
+func (this *Broker) flush(msg model.Message, sub model.Subscription, now time.Time) error {
+	if silenceId, ok := this.getMatchingSilence(msg, sub.Key, now); ok {
+		this.addSilencedMessage(model.SilencedMessage{Time: now, Message: msg, Subscriptions: []string{sub.Key}, Silences: []string{silenceId}})
+		return nil
+	}
+	if !sub.IsActiveAt(now) {
+		if sub.DefersInactiveMessages() {
+			return this.addDeferred(msg, sub, true)
+		}
+		this.config.GetLogger().Debug("drop message outside of active hours", "subscription", sub.Key, "title", msg.Title)
+		return nil
+	}
+	return this.deliver(msg, sub)
+}
+
+// deliver enqueues the message or, without delivery queue, sends it and stores it as dead letter on failure
+func (this *Broker) deliver(message model.Message, subscription model.Subscription) error {
+	if this.queue != nil {
+		return this.queue.Enqueue(model.Delivery{Subscription: subscription, Message: message})
+	}
+	err := this.send(message, subscription)
+	if err != nil {
+		this.addDeadLetter(model.Delivery{
+			Id:           model.NewId(time.Now()),
+			Subscription: subscription,
+			Message:      message,
+			Created:      time.Now(),
+			Attempts:     []model.DeliveryAttempt{{Time: time.Now(), Error: err.Error()}},
+		})
+	}
 	return err
 }
 
@@ -100,16 +304,23 @@ func (this *Broker) send(message model.Message, subscription model.Subscription)
 		return errors.New("unknown or unconfigured receiver (" + subscription.Receiver + ")")
 	}
 	this.config.GetLogger().Debug("send message to receiver", "receiver", subscription.Receiver)
-	return rec.Send(message, subscription.AdditionalReceiverInfo)
+	if threaded, ok := rec.(registry.ThreadedReceiver); ok {
+		return threaded.SendThreaded(message, string(subscription.AdditionalReceiverInfo), GetDistinctKey(message, subscription))
+	}
+	return rec.Send(message, string(subscription.AdditionalReceiverInfo))
 }
 
 func LoadSubscriptions(config configuration.Config) (subscriptions []model.Subscription, err error) {
+	return loadSubscriptions(config, false)
+}
+
+func loadSubscriptions(config configuration.Config, strict bool) (subscriptions []model.Subscription, err error) {
 	subscriptions, err = AddSubscriptions(subscriptions, config.Subscriptions)
 	if err != nil {
 		return nil, err
 	}
 	if config.SubscriptionFilesDir != "" && config.SubscriptionFilesDir != "-" {
-		subs, err := LoadSubscriptionFiles(config.SubscriptionFilesDir)
+		subs, err := loadSubscriptionFiles(config.SubscriptionFilesDir, strict)
 		if err != nil {
 			return nil, err
 		}
@@ -125,7 +336,7 @@ func AddSubscriptions(list, added []model.Subscription) (result []model.Subscrip
 	result = append(result, list...)
 	for _, sub := range added {
 		if !sub.Disabled {
-			sub.DistinctTimeWindowDuration, err = time.ParseDuration(sub.DistinctTimeWindow)
+			sub, err = PrepareSubscription(sub)
 			if err != nil {
 				return nil, err
 			}
@@ -134,3 +345,19 @@ func AddSubscriptions(list, added []model.Subscription) (result []model.Subscrip
 	}
 	return result, nil
 }
+
+// PrepareSubscription validates the subscription and sets derived fields like DistinctTimeWindowDuration;
+// digest and grouped subscriptions may omit the DistinctTimeWindow, because duplicates are grouped
+func PrepareSubscription(sub model.Subscription) (result model.Subscription, err error) {
+	if sub.DistinctTimeWindow != "" || !(sub.IsDigest() || sub.IsGrouped()) {
+		sub.DistinctTimeWindowDuration, err = time.ParseDuration(sub.DistinctTimeWindow)
+		if err != nil {
+			return sub, err
+		}
+	}
+	err = sub.Prepare()
+	if err != nil {
+		return sub, err
+	}
+	return sub, nil
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+// addDeadLetter stores deliveries that finally failed, so that they can be replayed after the cause is fixed
+func (this *Broker) addDeadLetter(delivery model.Delivery) {
+	err := this.deadLetters.Set(delivery.Id, delivery)
+	if err != nil {
+		this.config.GetLogger().Error("unable to store dead letter", "id", delivery.Id, "subscription", delivery.Subscription.Key, "error", err)
+	}
+}
+
+func (this *Broker) ListDeadLetters() (result []model.Delivery, err error) {
+	keys, err := this.deadLetters.Keys()
+	if err != nil {
+		return nil, err
+	}
+	result = []model.Delivery{}
+	for _, key := range keys {
+		element := model.Delivery{}
+		found, err := this.deadLetters.Get(key, &element)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			result = append(result, element)
+		}
+	}
+	return result, nil
+}
+
+func (this *Broker) GetDeadLetter(id string) (result model.Delivery, err error) {
+	found, err := this.deadLetters.Get(id, &result)
+	if err != nil {
+		return result, err
+	}
+	if !found {
+		return result, fmt.Errorf("%w: dead letter %v", model.ErrNotFound, id)
+	}
+	return result, nil
+}
+
+// ReplayDeadLetter sends the dead letter again, using the current version of its subscription if it still exists.
+// With a delivery queue the dead letter is queued as new delivery, otherwise it is sent immediately
+// and kept, with the additional attempt, if the send fails again.
+func (this *Broker) ReplayDeadLetter(id string) error {
+	deadLetter, err := this.GetDeadLetter(id)
+	if err != nil {
+		return err
+	}
+	subscription := deadLetter.Subscription
+	if current, err := this.GetSubscription(subscription.Key); err == nil {
+		subscription = current
+	} else if !errors.Is(err, model.ErrNotFound) {
+		return err
+	}
+	if this.queue != nil {
+		err = this.queue.Enqueue(model.Delivery{Subscription: subscription, Message: deadLetter.Message})
+		if err != nil {
+			return err
+		}
+		return this.deadLetters.Delete(id)
+	}
+	err = this.send(deadLetter.Message, subscription)
+	if err != nil {
+		deadLetter.Attempts = append(deadLetter.Attempts, model.DeliveryAttempt{Time: time.Now(), Error: err.Error()})
+		return errors.Join(err, this.deadLetters.Set(id, deadLetter))
+	}
+	return this.deadLetters.Delete(id)
+}
+
+func (this *Broker) DeleteDeadLetter(id string) error {
+	found, err := this.deadLetters.Get(id, &model.Delivery{})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("%w: dead letter %v", model.ErrNotFound, id)
+	}
+	return this.deadLetters.Delete(id)
+}
+
+func (this *Broker) PurgeDeadLetters() error {
+	keys, err := this.deadLetters.Keys()
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		err = this.deadLetters.Delete(key)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
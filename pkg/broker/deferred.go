@@ -0,0 +1,116 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+func (this *Broker) startDeferred(ctx context.Context, wg *sync.WaitGroup) (err error) {
+	if maxAge := this.config.DeferredMaxAge; maxAge != "" && maxAge != "-" {
+		this.deferredMaxAge, err = time.ParseDuration(maxAge)
+		if err != nil {
+			return fmt.Errorf("invalid deferred_max_age: %w", err)
+		}
+	}
+	ticker := time.NewTicker(this.dueCheckInterval)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				this.ReleaseDeferred(now)
+			}
+		}
+	}()
+	return nil
+}
+
+// deferredMessage is a message held back until its subscription is active again
+type deferredMessage struct {
+	model.Delivery
+	Flushed bool `json:"flushed,omitempty"` //created by a digest, group or summary and delivered without being handled again
+}
+
+// addDeferred holds the message back until the subscription is active again
+func (this *Broker) addDeferred(msg model.Message, sub model.Subscription, flushed bool) error {
+	now := time.Now()
+	id := model.NewId(now)
+	return this.deferred.Set(id, deferredMessage{Delivery: model.Delivery{Id: id, Subscription: sub, Message: msg, Created: now}, Flushed: flushed})
+}
+
+// ReleaseDeferred handles the deferred messages of subscriptions, which are active at the given time, in the order
+// they were received. The current version of the subscription is used; messages of removed subscriptions and messages
+// older than config.DeferredMaxAge are dropped.
+func (this *Broker) ReleaseDeferred(now time.Time) {
+	this.deferredMux.Lock()
+	defer this.deferredMux.Unlock()
+	keys, err := this.deferred.Keys()
+	if err != nil {
+		this.config.GetLogger().Error("unable to list deferred messages", "error", err)
+		return
+	}
+	active := this.getSubscriptions()
+	for _, key := range keys {
+		entry := deferredMessage{}
+		found, err := this.deferred.Get(key, &entry)
+		if err != nil {
+			this.config.GetLogger().Error("unable to read deferred message", "id", key, "error", err)
+			continue
+		}
+		if !found {
+			continue
+		}
+		expired := this.deferredMaxAge > 0 && now.Sub(entry.Created) > this.deferredMaxAge
+		index := slices.IndexFunc(active, func(sub model.Subscription) bool { return sub.Key == entry.Subscription.Key })
+		if index >= 0 && !expired && !active[index].IsActiveAt(now) {
+			continue
+		}
+		if expired {
+			this.config.GetLogger().Warn("drop expired deferred message", "subscription", entry.Subscription.Key, "created", entry.Created)
+		} else if index < 0 {
+			this.config.GetLogger().Debug("drop deferred message of removed subscription", "subscription", entry.Subscription.Key)
+		} else if silenceId, ok := this.getMatchingSilence(entry.Message, entry.Subscription.Key, now); ok {
+			this.addSilencedMessage(model.SilencedMessage{Time: now, Message: entry.Message, Subscriptions: []string{entry.Subscription.Key}, Silences: []string{silenceId}})
+		} else if err = this.releaseDeferred(entry, active[index]); err != nil {
+			this.config.GetLogger().Error("unable to send deferred message", "subscription", entry.Subscription.Key, "error", err)
+		}
+		err = this.deferred.Delete(key)
+		if err != nil {
+			this.config.GetLogger().Error("unable to delete deferred message", "id", key, "error", err)
+		}
+	}
+}
+
+// releaseDeferred delivers flushed messages directly and handles received messages like new ones
+func (this *Broker) releaseDeferred(entry deferredMessage, sub model.Subscription) (err error) {
+	if entry.Flushed {
+		return this.deliver(entry.Message, sub)
+	}
+	_, err = this.handle(entry.Message, sub)
+	return err
+}
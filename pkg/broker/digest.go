@@ -0,0 +1,133 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+// DigestSender is the sender of digest messages and of group messages with multiple senders
+const DigestSender = "developer-notifications"
+
+func (this *Broker) startDigests(ctx context.Context, wg *sync.WaitGroup) {
+	ticker := time.NewTicker(this.dueCheckInterval)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				this.SendDueDigests(now)
+			}
+		}
+	}()
+}
+
+func (this *Broker) addToDigest(msg model.Message, sub model.Subscription) error {
+	this.digestMux.Lock()
+	defer this.digestMux.Unlock()
+	now := time.Now()
+	digest := model.Digest{}
+	found, err := this.digests.Get(sub.Key, &digest)
+	if err != nil {
+		return err
+	}
+	if !found {
+		digest = model.Digest{SubscriptionKey: sub.Key, Created: now, Due: sub.NextDigest(now)}
+	}
+	digest.Add(msg, now)
+	return this.digests.Set(sub.Key, digest)
+}
+
+// SendDueDigests sends and removes all digests which are due at now. Groups of the digest, which are silenced at now,
+// are left out. Digests of subscriptions, which are no longer active, are dropped.
+func (this *Broker) SendDueDigests(now time.Time) {
+	due := []model.Digest{}
+	this.digestMux.Lock()
+	keys, err := this.digests.Keys()
+	if err != nil {
+		this.config.GetLogger().Error("unable to list digests", "error", err)
+	}
+	for _, key := range keys {
+		digest := model.Digest{}
+		found, err := this.digests.Get(key, &digest)
+		if err == nil && found && !digest.Due.After(now) {
+			due = append(due, digest)
+			err = this.digests.Delete(key)
+		}
+		if err != nil {
+			this.config.GetLogger().Error("unable to read digest", "subscription", key, "error", err)
+		}
+	}
+	this.digestMux.Unlock()
+
+	active := this.getSubscriptions()
+	for _, digest := range due {
+		index := slices.IndexFunc(active, func(sub model.Subscription) bool { return sub.Key == digest.SubscriptionKey })
+		if index < 0 {
+			this.config.GetLogger().Warn("drop digest of removed subscription", "subscription", digest.SubscriptionKey, "groups", len(digest.Groups))
+			continue
+		}
+		digest.Groups = slices.DeleteFunc(digest.Groups, func(group model.DigestGroup) bool {
+			return len(this.removeSilenced([]model.Message{group.Message()}, digest.SubscriptionKey, now)) == 0
+		})
+		if len(digest.Groups) == 0 {
+			continue
+		}
+		err = this.flush(CreateDigestMessage(digest), active[index], now)
+		if err != nil {
+			this.config.GetLogger().Error("unable to send digest", "subscription", digest.SubscriptionKey, "error", err)
+		}
+	}
+}
+
+// CreateDigestMessage summarizes the digest with one line per sender and title
+func CreateDigestMessage(digest model.Digest) model.Message {
+	total := 0
+	severity := model.Severity("")
+	lines := []string{}
+	for _, group := range digest.Groups {
+		total += group.Count
+		if group.Severity.Level() > severity.Level() {
+			severity = group.Severity
+		}
+		line := fmt.Sprintf("%vx [%v] %v: %v", group.Count, group.Severity, group.Sender, group.Title)
+		if group.Count > 1 {
+			line += fmt.Sprintf(" (%v - %v)", group.First.Local().Format(time.DateTime), group.Last.Local().Format(time.DateTime))
+		} else {
+			line += fmt.Sprintf(" (%v)", group.First.Local().Format(time.DateTime))
+		}
+		lines = append(lines, line)
+	}
+	return model.Message{
+		Sender:   DigestSender,
+		Title:    fmt.Sprintf("Digest %v: %v messages since %v", digest.SubscriptionKey, total, digest.Created.Local().Format(time.DateTime)),
+		Body:     strings.Join(lines, "\n"),
+		Severity: severity,
+		Labels:   map[string]string{"digest": digest.SubscriptionKey},
+	}
+}
@@ -19,20 +19,75 @@ package broker
 import (
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"strings"
+
 	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
 )
 
-func (this *Broker) IsDistinctMessage(msg model.Message, sub model.Subscription) bool {
-	key := sub.Key + "_" + hash(msg)
-	if this.existsInCache(key) {
-		return false
+// IsDistinctMessage checks the distinct time window of the subscription and counts suppressed duplicates;
+// if the message is distinct, the count of duplicates suppressed since it was last sent is returned as repetition.
+// Without time window, every message is distinct; the stores are not used, because they handle a zero window differently.
+func (this *Broker) IsDistinctMessage(msg model.Message, sub model.Subscription) (distinct bool, repetition *model.Repetition) {
+	if sub.DistinctTimeWindowDuration <= 0 {
+		return true, nil
+	}
+	key := GetDistinctKey(msg, sub)
+	isNew, repetition, err := this.distinctStore.Mark(key, sub.DistinctTimeWindowDuration)
+	if err != nil {
+		//rather send a duplicate than lose a message
+		this.config.GetLogger().Error("unable to check distinct time window", "subscription", sub.Key, "error", err)
+		return true, nil
+	}
+	if !isNew {
+		err = this.distinctStore.Suppress(key, sub.Key, msg)
+		if err != nil {
+			this.config.GetLogger().Error("unable to count suppressed duplicate", "subscription", sub.Key, "error", err)
+		}
+		return false, nil
+	}
+	return true, repetition
+}
+
+// GetDistinctKey identifies messages that are considered equal for the subscription;
+// only sub.DistinctFields are used if set and the body is normalized with sub.DistinctBodyReplacements
+func GetDistinctKey(msg model.Message, sub model.Subscription) string {
+	msg.Body = sub.NormalizeBody(msg.Body)
+	msg.Repetition = nil
+	if len(sub.DistinctFields) == 0 {
+		return sub.Key + "_" + hash(fmt.Sprintf("%#v", msg))
+	}
+	values := []any{}
+	for _, field := range sub.DistinctFields {
+		values = append(values, getFieldValue(msg, field))
+	}
+	b, _ := json.Marshal(values) //unambiguous, unlike a simple concatenation of the values
+	return sub.Key + "_" + hash(string(b))
+}
+
+// getFieldValue returns the value of a field in model.DistinctFields or of a "label:<name>" field
+func getFieldValue(msg model.Message, field string) any {
+	switch field {
+	case model.DistinctFieldSender:
+		return msg.Sender
+	case model.DistinctFieldTitle:
+		return msg.Title
+	case model.DistinctFieldBody:
+		return msg.Body
+	case model.DistinctFieldTags:
+		return msg.Tags
+	case model.DistinctFieldSeverity:
+		return msg.GetSeverity()
+	case model.DistinctFieldLabels:
+		return msg.Labels
+	default:
+		label, _ := strings.CutPrefix(field, model.DistinctFieldLabelPrefix)
+		return msg.Labels[label]
 	}
-	this.addToCache(key, sub.DistinctTimeWindowDuration)
-	return true
 }
 
-func hash(msg model.Message) string {
-	hashArr := sha256.Sum256([]byte(fmt.Sprintf("%#v", msg)))
+func hash(value string) string {
+	hashArr := sha256.Sum256([]byte(value))
 	return base64.StdEncoding.EncodeToString(hashArr[:])
 }
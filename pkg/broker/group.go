@@ -0,0 +1,185 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+func (this *Broker) startGroups(ctx context.Context, wg *sync.WaitGroup) {
+	ticker := time.NewTicker(this.dueCheckInterval)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				this.SendDueGroups(now)
+			}
+		}
+	}()
+}
+
+// GetGroupKey identifies the group of the message in a subscription with GroupBy
+func GetGroupKey(msg model.Message, sub model.Subscription) (key string, values map[string]string) {
+	list := []any{}
+	values = map[string]string{}
+	for _, field := range sub.GroupBy {
+		value := getFieldValue(msg, field)
+		list = append(list, value)
+		if str, ok := value.(string); ok {
+			values[field] = str
+		} else {
+			b, _ := json.Marshal(value)
+			values[field] = string(b)
+		}
+	}
+	b, _ := json.Marshal(list)
+	return sub.Key + "_" + hash(string(b)), values
+}
+
+// addToGroup adds the message to the pending messages of its group. The first pending message of a group is held
+// for the group wait; if the group has been notified within the group interval, until the group interval is over.
+func (this *Broker) addToGroup(msg model.Message, sub model.Subscription) error {
+	key, values := GetGroupKey(msg, sub)
+	this.groupMux.Lock()
+	defer this.groupMux.Unlock()
+	now := time.Now()
+	group := model.AlertGroup{}
+	found, err := this.groups.Get(key, &group)
+	if err != nil {
+		return err
+	}
+	if !found {
+		group = model.AlertGroup{Key: key, SubscriptionKey: sub.Key, Values: values}
+	}
+	if group.Count == 0 {
+		group.Due = now.Add(sub.GetGroupWait())
+		if next := group.LastSent.Add(sub.GetGroupInterval()); !group.LastSent.IsZero() && next.After(now) {
+			group.Due = next
+		}
+	}
+	group.Count++
+	if len(group.Messages) < model.MaxGroupMessages {
+		group.Messages = append(group.Messages, msg)
+	}
+	return this.groups.Set(key, group)
+}
+
+// SendDueGroups sends the pending messages of all groups which are due at now, except for messages silenced at now.
+// Groups without pending messages are removed after their group interval; groups of subscriptions, which are no longer
+// active, are dropped.
+func (this *Broker) SendDueGroups(now time.Time) {
+	active := this.getSubscriptions()
+	due := []model.AlertGroup{}
+	this.groupMux.Lock()
+	keys, err := this.groups.Keys()
+	if err != nil {
+		this.config.GetLogger().Error("unable to list groups", "error", err)
+	}
+	for _, key := range keys {
+		group := model.AlertGroup{}
+		found, err := this.groups.Get(key, &group)
+		if err != nil || !found {
+			if err != nil {
+				this.config.GetLogger().Error("unable to read group", "group", key, "error", err)
+			}
+			continue
+		}
+		index := slices.IndexFunc(active, func(sub model.Subscription) bool { return sub.Key == group.SubscriptionKey && sub.IsGrouped() })
+		switch {
+		case index < 0:
+			this.config.GetLogger().Warn("drop group of removed subscription", "subscription", group.SubscriptionKey, "count", group.Count)
+			err = this.groups.Delete(key)
+		case group.Count > 0 && !group.Due.After(now):
+			due = append(due, group)
+			group.Messages = nil
+			group.Count = 0
+			group.LastSent = now
+			err = this.groups.Set(key, group)
+		case group.Count == 0 && now.After(group.LastSent.Add(active[index].GetGroupInterval())):
+			err = this.groups.Delete(key)
+		}
+		if err != nil {
+			this.config.GetLogger().Error("unable to update group", "group", key, "error", err)
+		}
+	}
+	this.groupMux.Unlock()
+
+	for _, group := range due {
+		index := slices.IndexFunc(active, func(sub model.Subscription) bool { return sub.Key == group.SubscriptionKey })
+		pending := this.removeSilenced(group.Messages, group.SubscriptionKey, now)
+		group.Count -= len(group.Messages) - len(pending)
+		group.Messages = pending
+		if group.Count == 0 {
+			continue
+		}
+		err = this.flush(CreateGroupMessage(group), active[index], now)
+		if err != nil {
+			this.config.GetLogger().Error("unable to send group", "subscription", group.SubscriptionKey, "error", err)
+		}
+	}
+}
+
+// CreateGroupMessage returns a single pending message unchanged and otherwise summarizes the pending messages
+// with one line per message
+func CreateGroupMessage(group model.AlertGroup) model.Message {
+	if group.Count == 1 && len(group.Messages) == 1 {
+		return group.Messages[0]
+	}
+	result := model.Message{Sender: DigestSender, Labels: map[string]string{}}
+	lines := []string{}
+	if len(group.Messages) > 0 && !slices.ContainsFunc(group.Messages, func(msg model.Message) bool { return msg.Sender != group.Messages[0].Sender }) {
+		result.Sender = group.Messages[0].Sender
+	}
+	for _, msg := range group.Messages {
+		severity := msg.GetSeverity()
+		if severity.Level() > result.Severity.Level() {
+			result.Severity = severity
+		}
+		line := fmt.Sprintf("[%v] %v: %v", severity, msg.Sender, msg.Title)
+		if msg.Repetition != nil {
+			line += " (" + msg.Repetition.String() + ")"
+		}
+		lines = append(lines, line)
+	}
+	if more := group.Count - len(group.Messages); more > 0 {
+		lines = append(lines, fmt.Sprintf("... and %v more", more))
+	}
+	description := []string{}
+	for _, field := range slices.Sorted(maps.Keys(group.Values)) {
+		description = append(description, field+"="+group.Values[field])
+		if label, ok := strings.CutPrefix(field, model.DistinctFieldLabelPrefix); ok {
+			result.Labels[label] = group.Values[field]
+		}
+	}
+	result.Title = fmt.Sprintf("%v messages for %v", group.Count, strings.Join(description, ", "))
+	result.Body = strings.Join(lines, "\n")
+	return result
+}
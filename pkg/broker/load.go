@@ -18,23 +18,35 @@ package broker
 
 import (
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
 )
 
 func LoadSubscriptionFiles(dir string) (subscriptions []model.Subscription, err error) {
+	return loadSubscriptionFiles(dir, false)
+}
+
+// loadSubscriptionFiles skips invalid json files with a warning, except if strict is set.
+// Files and directories starting with "." are ignored, so that kubernetes configmap mounts, which contain the
+// files additionally in directories like "..2026_01_01_00_00_00.000000000", do not produce duplicates.
+func loadSubscriptionFiles(dir string, strict bool) (subscriptions []model.Subscription, err error) {
 	subscriptions = []model.Subscription{}
 	files, err := os.ReadDir(dir)
 	if err != nil {
 		return subscriptions, err
 	}
 	for _, file := range files {
+		if strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
 		p := filepath.Join(dir, file.Name())
 		if file.IsDir() {
-			temp, err := LoadSubscriptionFiles(p)
+			temp, err := loadSubscriptionFiles(p, strict)
 			if err != nil {
 				return subscriptions, err
 			}
@@ -46,6 +58,9 @@ func LoadSubscriptionFiles(dir string) (subscriptions []model.Subscription, err
 				//ignore and do not warn
 			case ".json":
 				temp, err := LoadJson(p)
+				if err != nil && strict {
+					return subscriptions, fmt.Errorf("unable to load subscription file %v: %w", p, err)
+				}
 				if err != nil {
 					slog.Warn("unable to load subscription file", "path", p, "error", err)
 					continue
@@ -65,6 +80,7 @@ func LoadJson(location string) (topicDescriptions []model.Subscription, err erro
 		slog.Error("unable to open file", "path", location, "error", err)
 		return topicDescriptions, err
 	}
+	defer file.Close()
 	err = json.NewDecoder(file).Decode(&topicDescriptions)
 	if err != nil {
 		slog.Error("unable to decode json", "path", location, "error", err)
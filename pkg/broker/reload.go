@@ -0,0 +1,176 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+// startSubscriptionReload reloads the subscriptions on SIGHUP and, if configured, when the SubscriptionFilesDir changes
+func (this *Broker) startSubscriptionReload(ctx context.Context, wg *sync.WaitGroup) error {
+	var tick <-chan time.Time
+	dir := this.config.SubscriptionFilesDir
+	interval := this.config.SubscriptionFilesReloadInterval
+	if dir != "" && dir != "-" && interval != "" && interval != "-" {
+		duration, err := time.ParseDuration(interval)
+		if err != nil {
+			return fmt.Errorf("invalid subscription_files_reload_interval: %w", err)
+		}
+		ticker := time.NewTicker(duration)
+		context.AfterFunc(ctx, ticker.Stop)
+		tick = ticker.C
+	}
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	fingerprint := subscriptionFilesFingerprint(dir)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer signal.Stop(hup)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hup:
+				this.config.GetLogger().Info("received SIGHUP, reload subscriptions")
+				_ = this.ReloadSubscriptions()
+			case <-tick:
+				current := subscriptionFilesFingerprint(dir)
+				if current != fingerprint {
+					fingerprint = current
+					this.config.GetLogger().Info("subscription files changed, reload subscriptions")
+					_ = this.ReloadSubscriptions()
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// ReloadSubscriptions reloads the subscriptions from the config and the SubscriptionFilesDir.
+// If any file is invalid or an added or changed subscription is rejected by its receiver, the last valid subscriptions are kept.
+func (this *Broker) ReloadSubscriptions() error {
+	subscriptions, err := loadSubscriptions(this.config, true)
+	if err != nil {
+		this.config.GetLogger().Error("unable to reload subscriptions, keep last valid subscriptions", "error", err)
+		return err
+	}
+	subscriptions = markReadOnly(subscriptions)
+
+	this.storeMux.Lock()
+	defer this.storeMux.Unlock()
+	added, removed, changed := diffSubscriptions(this.getStaticSubscriptions(), subscriptions)
+	err = this.checkReceivers(subscriptions, append(added, changed...))
+	if err != nil {
+		this.config.GetLogger().Error("unable to reload subscriptions, keep last valid subscriptions", "error", err)
+		return err
+	}
+	this.subscriptionsMux.Lock()
+	this.staticSubscriptions = subscriptions
+	this.subscriptionsMux.Unlock()
+	err = this.updateSubscriptions()
+	if err != nil {
+		this.config.GetLogger().Error("unable to update subscriptions", "error", err)
+		return err
+	}
+	this.config.GetLogger().Info("reloaded subscriptions", "added", added, "removed", removed, "changed", changed)
+	return nil
+}
+
+// checkReceivers checks the receivers of the subscriptions with the given keys; unchanged subscriptions are not checked,
+// because the initial load only ignores subscriptions of unconfigured receivers
+func (this *Broker) checkReceivers(subscriptions []model.Subscription, keys []string) error {
+	errorList := []error{}
+	for _, sub := range subscriptions {
+		if !slices.Contains(keys, sub.Key) {
+			continue
+		}
+		if err := this.checkReceiver(sub); err != nil {
+			errorList = append(errorList, fmt.Errorf("subscription %v: %w", sub.Key, err))
+		}
+	}
+	return errors.Join(errorList...)
+}
+
+func diffSubscriptions(old []model.Subscription, new []model.Subscription) (added []string, removed []string, changed []string) {
+	added, removed, changed = []string{}, []string{}, []string{}
+	oldIndex := map[string][]byte{}
+	for _, sub := range old {
+		oldIndex[sub.Key], _ = json.Marshal(sub)
+	}
+	newIndex := map[string][]byte{}
+	for _, sub := range new {
+		newIndex[sub.Key], _ = json.Marshal(sub)
+	}
+	for _, sub := range new {
+		prev, ok := oldIndex[sub.Key]
+		if !ok {
+			added = append(added, sub.Key)
+		} else if string(prev) != string(newIndex[sub.Key]) {
+			changed = append(changed, sub.Key)
+		}
+	}
+	for _, sub := range old {
+		if _, ok := newIndex[sub.Key]; !ok {
+			removed = append(removed, sub.Key)
+		}
+	}
+	return added, removed, changed
+}
+
+// subscriptionFilesFingerprint summarizes path, size and modification time of all files in dir.
+// os.Stat follows symlinks, so that updates of kubernetes configmap mounts are detected.
+func subscriptionFilesFingerprint(dir string) string {
+	if dir == "" || dir == "-" {
+		return ""
+	}
+	result := strings.Builder{}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		p := filepath.Join(dir, entry.Name())
+		info, err := os.Stat(p)
+		if err != nil {
+			result.WriteString(p + " error: " + err.Error() + "\n")
+			continue
+		}
+		if info.IsDir() {
+			result.WriteString(subscriptionFilesFingerprint(p))
+			continue
+		}
+		result.WriteString(fmt.Sprintln(p, info.Size(), info.ModTime().UnixNano()))
+	}
+	return result.String()
+}
@@ -0,0 +1,259 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+// SilenceCleanupInterval is the interval in which expired silences and old records of silenced messages are deleted
+var SilenceCleanupInterval = time.Hour
+
+func (this *Broker) startSilenceCleanup(ctx context.Context, wg *sync.WaitGroup) error {
+	retention := this.config.SilenceRetention
+	if retention == "" || retention == "-" {
+		return nil
+	}
+	duration, err := time.ParseDuration(retention)
+	if err != nil {
+		return fmt.Errorf("invalid silence_retention: %w", err)
+	}
+	ticker := time.NewTicker(SilenceCleanupInterval)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				this.CleanupSilences(now.Add(-duration))
+			}
+		}
+	}()
+	return nil
+}
+
+func (this *Broker) ListSilences() (result []model.Silence, err error) {
+	keys, err := this.silenceStore.Keys()
+	if err != nil {
+		return nil, err
+	}
+	result = []model.Silence{}
+	for _, key := range keys {
+		element := model.Silence{}
+		found, err := this.silenceStore.Get(key, &element)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			result = append(result, element)
+		}
+	}
+	return result, nil
+}
+
+func (this *Broker) GetSilence(id string) (result model.Silence, err error) {
+	found, err := this.silenceStore.Get(id, &result)
+	if err != nil {
+		return result, err
+	}
+	if !found {
+		return result, fmt.Errorf("%w: silence %v", model.ErrNotFound, id)
+	}
+	return result, nil
+}
+
+// CreateSilence stores the silence with a new id; the start defaults to now
+func (this *Broker) CreateSilence(silence model.Silence) (result model.Silence, err error) {
+	this.silenceMux.Lock()
+	defer this.silenceMux.Unlock()
+	now := time.Now()
+	silence.Id = model.NewId(now)
+	silence.Created = now
+	if silence.Start.IsZero() {
+		silence.Start = now
+	}
+	return silence, this.storeSilence(silence)
+}
+
+// SetSilence updates an existing silence, e.g. to expire it early by setting the end to now
+func (this *Broker) SetSilence(silence model.Silence) error {
+	this.silenceMux.Lock()
+	defer this.silenceMux.Unlock()
+	existing, err := this.GetSilence(silence.Id)
+	if err != nil {
+		return err
+	}
+	silence.Created = existing.Created
+	if silence.Start.IsZero() {
+		silence.Start = existing.Start
+	}
+	return this.storeSilence(silence)
+}
+
+func (this *Broker) DeleteSilence(id string) error {
+	this.silenceMux.Lock()
+	defer this.silenceMux.Unlock()
+	_, err := this.GetSilence(id)
+	if err != nil {
+		return err
+	}
+	err = this.silenceStore.Delete(id)
+	if err != nil {
+		return err
+	}
+	return this.updateSilences()
+}
+
+// storeSilence expects the caller to hold silenceMux
+func (this *Broker) storeSilence(silence model.Silence) error {
+	prepared := silence
+	err := prepared.Prepare()
+	if err != nil {
+		return err
+	}
+	err = this.silenceStore.Set(silence.Id, silence)
+	if err != nil {
+		return err
+	}
+	return this.updateSilences()
+}
+
+// updateSilences replaces the list of prepared silences, which have not yet expired
+func (this *Broker) updateSilences() error {
+	list, err := this.ListSilences()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	result := []model.Silence{}
+	for _, silence := range list {
+		if silence.IsExpired(now) {
+			continue
+		}
+		err = silence.Prepare()
+		if err != nil {
+			this.config.GetLogger().Warn("ignoring silence", "silence", silence.Id, "error", err)
+			continue
+		}
+		result = append(result, silence)
+	}
+	this.silencesMux.Lock()
+	defer this.silencesMux.Unlock()
+	this.silences = result
+	return nil
+}
+
+func (this *Broker) getSilences() []model.Silence {
+	this.silencesMux.RLock()
+	defer this.silencesMux.RUnlock()
+	return this.silences
+}
+
+// getMatchingSilence returns the id of the first active silence muting the message for the subscription
+func (this *Broker) getMatchingSilence(msg model.Message, subscriptionKey string, now time.Time) (id string, silenced bool) {
+	for _, silence := range this.getSilences() {
+		if silence.IsActive(now) && silence.Match(msg, subscriptionKey) {
+			return silence.Id, true
+		}
+	}
+	return "", false
+}
+
+// removeSilenced records and removes the messages, which are silenced for the subscription at now;
+// used for messages buffered before the silence was created
+func (this *Broker) removeSilenced(messages []model.Message, subscriptionKey string, now time.Time) (result []model.Message) {
+	for _, msg := range messages {
+		if silenceId, ok := this.getMatchingSilence(msg, subscriptionKey, now); ok {
+			this.addSilencedMessage(model.SilencedMessage{Time: now, Message: msg, Subscriptions: []string{subscriptionKey}, Silences: []string{silenceId}})
+		} else {
+			result = append(result, msg)
+		}
+	}
+	return result
+}
+
+// addSilencedMessage records the silenced message for audits
+func (this *Broker) addSilencedMessage(record model.SilencedMessage) {
+	record.Id = model.NewId(record.Time)
+	err := this.silencedMessages.Set(record.Id, record)
+	if err != nil {
+		this.config.GetLogger().Error("unable to store silenced message", "sender", record.Message.Sender, "title", record.Message.Title, "error", err)
+	}
+}
+
+func (this *Broker) ListSilencedMessages() (result []model.SilencedMessage, err error) {
+	keys, err := this.silencedMessages.Keys()
+	if err != nil {
+		return nil, err
+	}
+	result = []model.SilencedMessage{}
+	for _, key := range keys {
+		element := model.SilencedMessage{}
+		found, err := this.silencedMessages.Get(key, &element)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			result = append(result, element)
+		}
+	}
+	return result, nil
+}
+
+// CleanupSilences deletes silences, which expired before the given time, and older records of silenced messages
+func (this *Broker) CleanupSilences(before time.Time) {
+	this.silenceMux.Lock()
+	defer this.silenceMux.Unlock()
+	silences, err := this.ListSilences()
+	if err != nil {
+		this.config.GetLogger().Error("unable to list silences", "error", err)
+		return
+	}
+	for _, silence := range silences {
+		if silence.End.Before(before) {
+			err = this.silenceStore.Delete(silence.Id)
+			if err != nil {
+				this.config.GetLogger().Error("unable to delete expired silence", "silence", silence.Id, "error", err)
+			}
+		}
+	}
+	err = this.updateSilences()
+	if err != nil {
+		this.config.GetLogger().Error("unable to update silences", "error", err)
+	}
+	records, err := this.ListSilencedMessages()
+	if err != nil {
+		this.config.GetLogger().Error("unable to list silenced messages", "error", err)
+		return
+	}
+	for _, record := range records {
+		if record.Time.Before(before) {
+			err = this.silencedMessages.Delete(record.Id)
+			if err != nil {
+				this.config.GetLogger().Error("unable to delete silenced message", "id", record.Id, "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,219 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/receiver/registry"
+)
+
+func (this *Broker) ListSubscriptions() (result []model.Subscription, err error) {
+	static := this.getStaticSubscriptions()
+	result = append(result, static...)
+	stored, err := this.loadStoredSubscriptions()
+	if err != nil {
+		return nil, err
+	}
+	stored, _ = removeShadowed(static, stored)
+	return append(result, stored...), nil
+}
+
+func (this *Broker) GetSubscription(key string) (result model.Subscription, err error) {
+	list, err := this.ListSubscriptions()
+	if err != nil {
+		return result, err
+	}
+	for _, sub := range list {
+		if sub.Key == key {
+			return sub, nil
+		}
+	}
+	return result, model.ErrNotFound
+}
+
+func (this *Broker) CreateSubscription(sub model.Subscription) error {
+	this.storeMux.Lock()
+	defer this.storeMux.Unlock()
+	if err := this.checkWritable(sub.Key); err != nil {
+		return err
+	}
+	found, err := this.subscriptionStore.Get(sub.Key, &model.Subscription{})
+	if err != nil {
+		return err
+	}
+	if found {
+		return fmt.Errorf("%w: subscription %v", model.ErrAlreadyExists, sub.Key)
+	}
+	return this.storeSubscription(sub)
+}
+
+func (this *Broker) SetSubscription(sub model.Subscription) error {
+	this.storeMux.Lock()
+	defer this.storeMux.Unlock()
+	if err := this.checkWritable(sub.Key); err != nil {
+		return err
+	}
+	return this.storeSubscription(sub)
+}
+
+// DeleteSubscription also deletes stored subscriptions, which are shadowed by a subscription of the configuration
+func (this *Broker) DeleteSubscription(key string) error {
+	this.storeMux.Lock()
+	defer this.storeMux.Unlock()
+	found, err := this.subscriptionStore.Get(key, &model.Subscription{})
+	if err != nil {
+		return err
+	}
+	if !found {
+		if err := this.checkWritable(key); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: subscription %v", model.ErrNotFound, key)
+	}
+	err = this.subscriptionStore.Delete(key)
+	if err != nil {
+		return err
+	}
+	return this.updateSubscriptions()
+}
+
+func (this *Broker) checkWritable(key string) error {
+	if key == "" {
+		return fmt.Errorf("%w: missing subscription key", model.ErrInvalid)
+	}
+	if slices.ContainsFunc(this.getStaticSubscriptions(), func(sub model.Subscription) bool { return sub.Key == key }) {
+		return fmt.Errorf("%w: subscription %v is defined by the configuration", model.ErrReadOnly, key)
+	}
+	return nil
+}
+
+// storeSubscription expects the caller to hold storeMux
+func (this *Broker) storeSubscription(sub model.Subscription) error {
+	sub.ReadOnly = false
+	_, err := PrepareSubscription(sub)
+	if err != nil {
+		return errors.Join(model.ErrInvalid, err)
+	}
+	err = this.checkReceiver(sub)
+	if err != nil {
+		return errors.Join(model.ErrInvalid, err)
+	}
+	err = this.subscriptionStore.Set(sub.Key, sub)
+	if err != nil {
+		return err
+	}
+	return this.updateSubscriptions()
+}
+
+func (this *Broker) loadStoredSubscriptions() (result []model.Subscription, err error) {
+	keys, err := this.subscriptionStore.Keys()
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		sub := model.Subscription{}
+		found, err := this.subscriptionStore.Get(key, &sub)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			result = append(result, sub)
+		}
+	}
+	return result, nil
+}
+
+// updateSubscriptions combines the static subscriptions with the stored ones and replaces the active list
+func (this *Broker) updateSubscriptions() error {
+	stored, err := this.loadStoredSubscriptions()
+	if err != nil {
+		return err
+	}
+	static := this.getStaticSubscriptions()
+	stored, shadowed := removeShadowed(static, stored)
+	if len(shadowed) > 0 {
+		this.config.GetLogger().Warn("ignoring stored subscriptions with the key of a subscription from the configuration", "subscriptions", shadowed)
+	}
+	subscriptions, err := AddSubscriptions(static, stored)
+	if err != nil {
+		return err
+	}
+	active := []model.Subscription{}
+	for _, sub := range subscriptions {
+		if err := this.checkReceiver(sub); err != nil {
+			this.config.GetLogger().Warn("ignoring subscription", "receiver", sub.Receiver, "subscription", sub.Key, "error", err)
+		} else {
+			active = append(active, sub)
+		}
+	}
+	this.subscriptionsMux.Lock()
+	defer this.subscriptionsMux.Unlock()
+	this.subscriptions = active
+	return nil
+}
+
+// checkReceiver returns an error if the receiver of the subscription is unknown or rejects its AdditionalReceiverInfo
+func (this *Broker) checkReceiver(sub model.Subscription) error {
+	rec, ok := this.receivers.Get(sub.Receiver)
+	if !ok {
+		return errors.New("unknown or unconfigured receiver (" + sub.Receiver + ")")
+	}
+	if validator, ok := rec.(registry.InfoValidator); ok {
+		err := validator.ValidateAdditionalInfo(string(sub.AdditionalReceiverInfo))
+		if err != nil {
+			return fmt.Errorf("invalid additional_receiver_info for %v: %w", sub.Receiver, err)
+		}
+	}
+	return nil
+}
+
+// removeShadowed removes stored subscriptions with the key of a static subscription,
+// e.g. after a reload added a subscription file with the key of a subscription created by the api
+func removeShadowed(static []model.Subscription, stored []model.Subscription) (result []model.Subscription, shadowed []string) {
+	for _, sub := range stored {
+		if slices.ContainsFunc(static, func(element model.Subscription) bool { return element.Key == sub.Key }) {
+			shadowed = append(shadowed, sub.Key)
+		} else {
+			result = append(result, sub)
+		}
+	}
+	return result, shadowed
+}
+
+func (this *Broker) getSubscriptions() []model.Subscription {
+	this.subscriptionsMux.RLock()
+	defer this.subscriptionsMux.RUnlock()
+	return this.subscriptions
+}
+
+func (this *Broker) getStaticSubscriptions() []model.Subscription {
+	this.subscriptionsMux.RLock()
+	defer this.subscriptionsMux.RUnlock()
+	return this.staticSubscriptions
+}
+
+func markReadOnly(list []model.Subscription) (result []model.Subscription) {
+	for _, sub := range list {
+		sub.ReadOnly = true
+		result = append(result, sub)
+	}
+	return result
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+func (this *Broker) startSummaries(ctx context.Context, wg *sync.WaitGroup) error {
+	interval := this.config.DistinctSummaryInterval
+	if interval == "" || interval == "-" {
+		return nil
+	}
+	duration, err := time.ParseDuration(interval)
+	if err != nil {
+		return fmt.Errorf("invalid distinct_summary_interval: %w", err)
+	}
+	ticker := time.NewTicker(duration)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				this.SendSummaries()
+			}
+		}
+	}()
+	return nil
+}
+
+// SendSummaries sends the latest suppressed duplicate of each distinct key with the count of suppressed duplicates
+// and resets the counts. Summaries for subscriptions, which are no longer active, are dropped; silenced summaries are recorded.
+func (this *Broker) SendSummaries() {
+	list, err := this.distinctStore.TakeAll()
+	if err != nil {
+		this.config.GetLogger().Error("unable to read suppressed duplicates", "error", err)
+	}
+	active := this.getSubscriptions()
+	for _, entry := range list {
+		index := slices.IndexFunc(active, func(sub model.Subscription) bool { return sub.Key == entry.SubscriptionKey })
+		if index < 0 {
+			this.config.GetLogger().Debug("drop summary of removed subscription", "subscription", entry.SubscriptionKey, "count", entry.Repetition.Count)
+			continue
+		}
+		sub := active[index]
+		message := entry.Message
+		message.Repetition = &entry.Repetition
+		err := this.flush(message, sub, time.Now())
+		if err != nil {
+			this.config.GetLogger().Error("unable to send summary", "subscription", sub.Key, "error", err)
+		}
+	}
+}
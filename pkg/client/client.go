@@ -20,6 +20,15 @@ import "github.com/SENERGY-Platform/developer-notifications/pkg/model"
 
 type Message = model.Message
 
+type Severity = model.Severity
+
+const SeverityDebug = model.SeverityDebug
+const SeverityInfo = model.SeverityInfo
+const SeverityNotice = model.SeverityNotice
+const SeverityWarning = model.SeverityWarning
+const SeverityError = model.SeverityError
+const SeverityCritical = model.SeverityCritical
+
 type Client interface {
 	SendMessage(message Message) error
 }
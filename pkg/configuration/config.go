@@ -38,17 +38,92 @@ type Config struct {
 
 	SlackWebhookUrl string `json:"slack_webhook_url" config:"secret"`
 
+	//named webhooks, referenced by the additional_receiver_info of slack subscriptions (e.g. "team-a" or {"webhook": "team-a", "channel": "#alerts"})
+	SlackWebhooks map[string]string `json:"slack_webhooks" config:"secret"`
+
+	//if set, messages are posted with chat.postMessage to SlackChannel or the channel of the subscription,
+	//except for subscriptions that reference a webhook
+	SlackBotToken string `json:"slack_bot_token" config:"secret"`
+	SlackChannel  string `json:"slack_channel"`
+	SlackApiUrl   string `json:"slack_api_url"` //defaults to https://slack.com/api
+
+	//render messages as block kit layout
+	SlackBlockKit bool `json:"slack_block_kit"`
+
+	//with SlackBotToken, repeated messages are posted as reply to the first one; the threads are persisted in DataDir
+	SlackThreads         bool   `json:"slack_threads"`
+	SlackThreadBroadcast bool   `json:"slack_thread_broadcast"` //also send thread replies to the channel
+	SlackThreadMaxAge    string `json:"slack_thread_max_age"`   //age after which a repeated message starts a new thread, defaults to "24h"
+
+	//named header values for webhook subscriptions, referenced by the secret_headers of their additional_receiver_info
+	//(e.g. {"ops-token": "Bearer <token>"} and {"secret_headers": {"Authorization": "ops-token"}})
+	WebhookSecrets map[string]string `json:"webhook_secrets" config:"secret"`
+
+	TeamsWebhookUrl string `json:"teams_webhook_url" config:"secret"`
+
 	MailSmtpHost string `json:"mail_smtp_host"`
 	MailSmtpPort string `json:"mail_smtp_port"`
 	MailFrom     string `json:"mail_from" config:"secret"`
 	MailPassword string `json:"mail_password" config:"secret"`
+	MailUsername string `json:"mail_username" config:"secret"` //defaults to the address of MailFrom
+
+	//"starttls" (default, used if offered by the server), "starttls_required", "tls" (implicit tls, e.g. port 465) or "none"
+	MailTls           string `json:"mail_tls"`
+	MailTlsCaFile     string `json:"mail_tls_ca_file"`     //pem file with additional trusted certificates
+	MailTlsServerName string `json:"mail_tls_server_name"` //defaults to MailSmtpHost
+
+	//"plain" (default), "login", "cram-md5" or "none" for relays without authentication
+	MailAuth string `json:"mail_auth"`
 
 	//enables configuration of additional subscriptions without the need to change the config.json
 	//ref pkg/tests/testdata/subscriptions
+	//files and directories starting with "." are ignored; subscriptions stored by the api with the same key as a
+	//subscription from a file are inactive until the file subscription is removed
 	SubscriptionFilesDir string `json:"subscription_files_dir"`
 
+	//interval in which the SubscriptionFilesDir is checked for changes (e.g. "30s")
+	//if empty or "-", subscription files are only reloaded on SIGHUP
+	SubscriptionFilesReloadInterval string `json:"subscription_files_reload_interval"`
+
 	Subscriptions []model.Subscription `json:"subscriptions"`
 
+	//duplicates suppressed by the distinct_time_window of a subscription are counted and the count is added to the
+	//next message passing the window; additionally the counts are sent as summary in this interval (e.g. "15m")
+	//if empty or "-", counts are only added to the next message
+	DistinctSummaryInterval string `json:"distinct_summary_interval"`
+
+	//interval in which due digests, alert groups and deferred messages are sent (e.g. "10s"), defaults to 1s
+	DueCheckInterval string `json:"due_check_interval"`
+
+	//"memory" (default), "file" to keep distinct time windows and counts of suppressed duplicates in DataDir across restarts
+	//or "redis" to share them between multiple replicas
+	DistinctStore         string `json:"distinct_store"`
+	DistinctRedisAddr     string `json:"distinct_redis_addr"` //host:port
+	DistinctRedisPassword string `json:"distinct_redis_password" config:"secret"`
+	DistinctRedisDb       int    `json:"distinct_redis_db"`
+	DistinctRedisPrefix   string `json:"distinct_redis_prefix"` //prefix of all keys, defaults to "developer-notifications:"
+
+	//expired silences and records of silenced messages are deleted after this duration (e.g. "720h")
+	//if empty or "-", they are kept
+	SilenceRetention string `json:"silence_retention"`
+
+	//messages deferred outside the active hours of their subscription are dropped after this duration (e.g. "168h")
+	//if empty or "-", they are kept until the subscription is active again
+	DeferredMaxAge string `json:"deferred_max_age"`
+
+	//directory for persistent state like subscriptions created by the api
+	//if empty or "-", state is only kept in memory
+	DataDir string `json:"data_dir"`
+
+	//if true, messages are queued per receiver and POST /messages returns as soon as the message is queued
+	//failed deliveries are retried with exponential backoff; the queue is persisted in DataDir
+	//(without DataDir, the queue is only kept in memory)
+	DeliveryQueue          bool    `json:"delivery_queue"`
+	DeliveryMaxAttempts    int     `json:"delivery_max_attempts"`
+	DeliveryBackoffInitial string  `json:"delivery_backoff_initial"`
+	DeliveryBackoffMax     string  `json:"delivery_backoff_max"`
+	DeliveryBackoffJitter  float64 `json:"delivery_backoff_jitter"` //randomizes each backoff by +/- this fraction
+
 	LogLevel string       `json:"log_level"`
 	logger   *slog.Logger `json:"-"`
 }
@@ -120,7 +195,7 @@ func handleEnvironmentVars(config *Config) {
 			if configValue.FieldByName(fieldName).Kind() == reflect.Map {
 				value := map[string]string{}
 				for _, element := range strings.Split(envValue, ",") {
-					keyVal := strings.Split(element, ":")
+					keyVal := strings.SplitN(element, ":", 2) //values may contain ":", e.g. urls
 					key := strings.TrimSpace(keyVal[0])
 					val := strings.TrimSpace(keyVal[1])
 					value[key] = val
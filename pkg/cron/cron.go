@@ -0,0 +1,200 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression with the fields minute, hour, day of month, month and day of week
+type Schedule struct {
+	minute   uint64
+	hour     uint64
+	dom      uint64
+	month    uint64
+	dow      uint64
+	anyDom   bool
+	anyDow   bool
+	location *time.Location
+}
+
+var shortcuts = map[string]string{
+	"@yearly":  "0 0 1 1 *",
+	"@monthly": "0 0 1 * *",
+	"@weekly":  "0 0 * * 0",
+	"@daily":   "0 0 * * *",
+	"@hourly":  "0 * * * *",
+}
+
+var monthNames = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+var dayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// Parse parses expressions like "*/15 8-17 * * mon-fri", the shortcuts @hourly, @daily, @weekly, @monthly and @yearly
+// and an optional time zone prefix like "CRON_TZ=Europe/Berlin 0 9 * * *". Without prefix, the local time zone is used.
+func Parse(spec string) (result *Schedule, err error) {
+	result = &Schedule{location: time.Local}
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, "CRON_TZ=") || strings.HasPrefix(spec, "TZ=") {
+		zone, rest, _ := strings.Cut(spec, " ")
+		_, name, _ := strings.Cut(zone, "=")
+		result.location, err = time.LoadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron time zone %#v: %w", name, err)
+		}
+		spec = strings.TrimSpace(rest)
+	}
+	if shortcut, ok := shortcuts[spec]; ok {
+		spec = shortcut
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %#v: expected 5 fields", spec)
+	}
+	if result.minute, err = parseField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("invalid cron minute %#v: %w", fields[0], err)
+	}
+	if result.hour, err = parseField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("invalid cron hour %#v: %w", fields[1], err)
+	}
+	if result.dom, err = parseField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("invalid cron day of month %#v: %w", fields[2], err)
+	}
+	if result.month, err = parseField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("invalid cron month %#v: %w", fields[3], err)
+	}
+	if result.dow, err = parseField(fields[4], 0, 7, dayNames); err != nil {
+		return nil, fmt.Errorf("invalid cron day of week %#v: %w", fields[4], err)
+	}
+	if result.dow&(1<<7) != 0 {
+		result.dow |= 1 //7 is an alias for sunday
+	}
+	result.anyDom = fields[2] == "*" || fields[2] == "?"
+	result.anyDow = fields[4] == "*" || fields[4] == "?"
+	//with a restricted day of week, either day field may match (see matchDay), so only the day of month has to be checked
+	if result.anyDow && !result.hasDayOfMonth() {
+		return nil, fmt.Errorf("invalid cron expression %#v: the days of month never occur in the months", spec)
+	}
+	return result, nil
+}
+
+// daysInMonth contains the maximal number of days of each month, including february 29
+var daysInMonth = []int{1: 31, 2: 29, 3: 31, 4: 30, 5: 31, 6: 30, 7: 31, 8: 31, 9: 30, 10: 31, 11: 30, 12: 31}
+
+// hasDayOfMonth returns false for schedules like "0 0 30 feb *", which would never be due
+func (this *Schedule) hasDayOfMonth() bool {
+	for month := 1; month <= 12; month++ {
+		if this.month&(1<<uint(month)) == 0 {
+			continue
+		}
+		for day := 1; day <= daysInMonth[month]; day++ {
+			if this.dom&(1<<uint(day)) != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseField returns a bit set of the values in field; names are mapped to min, min+1, ...
+func parseField(field string, min int, max int, names []string) (result uint64, err error) {
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return 0, errors.New("invalid step " + stepPart)
+			}
+		}
+		start, end := min, max
+		if rangePart != "*" && rangePart != "?" {
+			startPart, endPart, isRange := strings.Cut(rangePart, "-")
+			start, err = parseValue(startPart, min, max, names)
+			if err != nil {
+				return 0, err
+			}
+			end = start
+			if isRange {
+				end, err = parseValue(endPart, min, max, names)
+				if err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				end = max
+			}
+			if end < start {
+				return 0, errors.New("invalid range " + rangePart)
+			}
+		}
+		for i := start; i <= end; i += step {
+			result |= 1 << uint(i)
+		}
+	}
+	return result, nil
+}
+
+func parseValue(value string, min int, max int, names []string) (int, error) {
+	for i, name := range names {
+		if strings.EqualFold(value, name) {
+			return min + i, nil
+		}
+	}
+	result, err := strconv.Atoi(value)
+	if err != nil || result < min || result > max {
+		return 0, fmt.Errorf("value %#v out of range %v-%v", value, min, max)
+	}
+	return result, nil
+}
+
+// Next returns the first scheduled time after t or the zero time, if there is none within the next 5 years
+func (this *Schedule) Next(t time.Time) time.Time {
+	t = t.In(this.location).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		prev := t
+		switch {
+		case this.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, this.location)
+		case !this.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, this.location)
+		case this.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, this.location)
+		case this.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+		if !t.After(prev) {
+			t = prev.Add(time.Hour) //time.Date may not advance at daylight saving time transitions
+		}
+	}
+	return time.Time{}
+}
+
+// matchDay uses the cron convention: if day of month and day of week are both restricted, either has to match
+func (this *Schedule) matchDay(t time.Time) bool {
+	domMatch := this.dom&(1<<uint(t.Day())) != 0
+	dowMatch := this.dow&(1<<uint(t.Weekday())) != 0
+	if !this.anyDom && !this.anyDow {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
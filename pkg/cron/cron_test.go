@@ -0,0 +1,62 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSchedule_Next(t *testing.T) {
+	start := time.Date(2026, 3, 6, 10, 2, 30, 0, time.UTC) //friday
+	tests := []struct {
+		spec     string
+		expected time.Time
+	}{
+		{spec: "* * * * *", expected: time.Date(2026, 3, 6, 10, 3, 0, 0, time.UTC)},
+		{spec: "*/15 * * * *", expected: time.Date(2026, 3, 6, 10, 15, 0, 0, time.UTC)},
+		{spec: "0 9 * * *", expected: time.Date(2026, 3, 7, 9, 0, 0, 0, time.UTC)},
+		{spec: "0 9 * * mon-fri", expected: time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)},
+		{spec: "30 8-17/4 * * *", expected: time.Date(2026, 3, 6, 12, 30, 0, 0, time.UTC)},
+		{spec: "0 0 1 * *", expected: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{spec: "0 0 29 feb *", expected: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{spec: "0 12 13 * 5", expected: time.Date(2026, 3, 6, 12, 0, 0, 0, time.UTC)}, //day of month or day of week
+		{spec: "0 0 * * 7", expected: time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)},
+		{spec: "0 0 31 2 fri", expected: time.Date(2027, 2, 5, 0, 0, 0, 0, time.UTC)}, //only the day of week can match
+		{spec: "0 0 31 1,4 *", expected: time.Date(2027, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{spec: "0,5 10 * * *", expected: time.Date(2026, 3, 6, 10, 5, 0, 0, time.UTC)},
+		{spec: "@hourly", expected: time.Date(2026, 3, 6, 11, 0, 0, 0, time.UTC)},
+		{spec: "@weekly", expected: time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)},
+		{spec: "CRON_TZ=Europe/Berlin 0 12 * * *", expected: time.Date(2026, 3, 6, 11, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		schedule, err := Parse(test.spec)
+		if err != nil {
+			t.Error(test.spec, err)
+			continue
+		}
+		if actual := schedule.Next(start); !actual.Equal(test.expected) {
+			t.Error(test.spec, actual, test.expected)
+		}
+	}
+
+	for _, invalid := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "5-1 * * * *", "*/0 * * * *", "* * * foo *", "TZ=Mars/Base 0 0 * * *", "0 0 30 2 *", "0 0 31 apr,jun,sep,nov *"} {
+		if _, err := Parse(invalid); err == nil {
+			t.Error("expected error for", invalid)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	mathrand "math/rand/v2"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/kv"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+const DefaultMaxAttempts = 10
+const DefaultBackoffInitial = time.Second
+const DefaultBackoffMax = 10 * time.Minute
+
+type SendFunc func(delivery model.Delivery) error
+
+// GiveUpFunc is called with deliveries that failed DeliveryMaxAttempts times
+type GiveUpFunc func(delivery model.Delivery)
+
+// New starts one worker per receiver. Each worker sends the queued deliveries of a subscription in order of creation;
+// a failed delivery holds back the later deliveries of its subscription, but not those of other subscriptions.
+func New(ctx context.Context, wg *sync.WaitGroup, config configuration.Config, receivers []string, send SendFunc, giveUp GiveUpFunc) (queue *Queue, err error) {
+	queue = &Queue{
+		config:         config,
+		send:           send,
+		giveUp:         giveUp,
+		maxAttempts:    config.DeliveryMaxAttempts,
+		backoffInitial: DefaultBackoffInitial,
+		backoffMax:     DefaultBackoffMax,
+		jitter:         config.DeliveryBackoffJitter,
+		workers:        map[string]*worker{},
+	}
+	if queue.maxAttempts <= 0 {
+		queue.maxAttempts = DefaultMaxAttempts
+	}
+	if config.DeliveryBackoffInitial != "" && config.DeliveryBackoffInitial != "-" {
+		queue.backoffInitial, err = time.ParseDuration(config.DeliveryBackoffInitial)
+		if err != nil {
+			return nil, fmt.Errorf("invalid delivery_backoff_initial: %w", err)
+		}
+	}
+	if config.DeliveryBackoffMax != "" && config.DeliveryBackoffMax != "-" {
+		queue.backoffMax, err = time.ParseDuration(config.DeliveryBackoffMax)
+		if err != nil {
+			return nil, fmt.Errorf("invalid delivery_backoff_max: %w", err)
+		}
+	}
+	if config.DataDir == "" || config.DataDir == "-" {
+		config.GetLogger().Warn("delivery_queue without data_dir: queued deliveries are only kept in memory and lost on restart")
+	}
+	for _, receiver := range receivers {
+		store, err := kv.New(config.DataDir, path.Join("queue", receiver))
+		if err != nil {
+			return nil, err
+		}
+		w := &worker{receiver: receiver, store: store, notify: make(chan struct{}, 1)}
+		queue.workers[receiver] = w
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			queue.run(ctx, w)
+		}()
+	}
+	return queue, nil
+}
+
+type Queue struct {
+	config         configuration.Config
+	send           SendFunc
+	giveUp         GiveUpFunc
+	maxAttempts    int
+	backoffInitial time.Duration
+	backoffMax     time.Duration
+	jitter         float64
+	workers        map[string]*worker
+}
+
+type worker struct {
+	receiver string
+	store    kv.Store
+	notify   chan struct{}
+}
+
+func (this *Queue) Enqueue(delivery model.Delivery) error {
+	w, ok := this.workers[delivery.Subscription.Receiver]
+	if !ok {
+		return errors.New("unknown or unconfigured receiver (" + delivery.Subscription.Receiver + ")")
+	}
+	now := time.Now()
+	if delivery.Id == "" {
+		delivery.Id = model.NewId(now)
+	}
+	if delivery.Created.IsZero() {
+		delivery.Created = now
+	}
+	if delivery.NextAttempt.IsZero() {
+		delivery.NextAttempt = now
+	}
+	err := w.store.Set(delivery.Id, delivery)
+	if err != nil {
+		return err
+	}
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (this *Queue) run(ctx context.Context, w *worker) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.notify:
+		case <-timer.C:
+		}
+		next := this.processDue(ctx, w)
+		timer.Stop()
+		timer.Reset(time.Until(next))
+	}
+}
+
+// processDue sends all due deliveries and returns when the next delivery is due.
+// Deliveries of a subscription are skipped, as long as an earlier delivery of the same subscription is pending.
+func (this *Queue) processDue(ctx context.Context, w *worker) (next time.Time) {
+	next = time.Now().Add(time.Minute)
+	pending := map[string]bool{} //subscription keys with a delivery that is not yet due
+	keys, err := w.store.Keys()
+	if err != nil {
+		this.config.GetLogger().Error("unable to list delivery queue", "receiver", w.receiver, "error", err)
+		return next
+	}
+	for _, key := range keys {
+		if ctx.Err() != nil {
+			return next
+		}
+		delivery := model.Delivery{}
+		found, err := w.store.Get(key, &delivery)
+		if err != nil {
+			this.config.GetLogger().Error("unable to read queued delivery, drop it", "receiver", w.receiver, "id", key, "error", err)
+			_ = w.store.Delete(key)
+			continue
+		}
+		if !found || pending[delivery.Subscription.Key] {
+			continue
+		}
+		if delivery.NextAttempt.After(time.Now()) {
+			pending[delivery.Subscription.Key] = true
+			if delivery.NextAttempt.Before(next) {
+				next = delivery.NextAttempt
+			}
+			continue
+		}
+		err = this.send(delivery)
+		if err == nil {
+			err = w.store.Delete(key)
+			if err != nil {
+				this.config.GetLogger().Error("unable to remove delivery from queue", "receiver", w.receiver, "id", key, "error", err)
+			}
+			continue
+		}
+		delivery.Attempts = append(delivery.Attempts, model.DeliveryAttempt{Time: time.Now(), Error: err.Error()})
+		if len(delivery.Attempts) >= this.maxAttempts {
+			this.config.GetLogger().Error("giving up delivery", "receiver", w.receiver, "id", key, "subscription", delivery.Subscription.Key, "attempts", len(delivery.Attempts), "error", err)
+			if this.giveUp != nil {
+				this.giveUp(delivery)
+			}
+			err = w.store.Delete(key)
+			if err != nil {
+				this.config.GetLogger().Error("unable to remove delivery from queue", "receiver", w.receiver, "id", key, "error", err)
+			}
+			continue
+		}
+		delivery.NextAttempt = time.Now().Add(this.backoff(len(delivery.Attempts)))
+		this.config.GetLogger().Warn("delivery failed, retry later", "receiver", w.receiver, "id", key, "subscription", delivery.Subscription.Key, "attempts", len(delivery.Attempts), "next_attempt", delivery.NextAttempt, "error", err)
+		err = w.store.Set(key, delivery)
+		if err != nil {
+			this.config.GetLogger().Error("unable to update queued delivery", "receiver", w.receiver, "id", key, "error", err)
+		}
+		pending[delivery.Subscription.Key] = true
+		if delivery.NextAttempt.Before(next) {
+			next = delivery.NextAttempt
+		}
+	}
+	return next
+}
+
+// backoff doubles the initial duration for every failed attempt, up to backoffMax, randomized by jitter
+func (this *Queue) backoff(attempts int) time.Duration {
+	result := float64(this.backoffInitial) * math.Pow(2, float64(attempts-1))
+	if result > float64(this.backoffMax) {
+		result = float64(this.backoffMax)
+	}
+	if this.jitter > 0 {
+		result = result * (1 + this.jitter*(2*mathrand.Float64()-1))
+	}
+	return time.Duration(result)
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distinct
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+// SuppressedRetention limits how long counts of suppressed duplicates are kept,
+// if the message does not pass the distinct time window again and no summary is sent
+const SuppressedRetention = 24 * time.Hour
+
+const StoreMemory = "memory"
+const StoreFile = "file"
+const StoreRedis = "redis"
+
+// Store keeps the distinct time windows and the counts of suppressed duplicates by distinct key.
+// Implementations are safe for concurrent use: local stores use a mutex, the redis store relies on SET NX and MULTI/EXEC.
+type Store interface {
+	// Mark starts the time window of key and returns true, if no window of key is active.
+	// For a new window, the count of duplicates suppressed since the last one is returned and reset (nil if there are none).
+	Mark(key string, window time.Duration) (isNew bool, repetition *model.Repetition, err error)
+
+	// Suppress counts a suppressed duplicate of key; the latest message is kept for summaries
+	Suppress(key string, subscriptionKey string, message model.Message) error
+
+	// TakeAll returns and resets all counts of suppressed duplicates
+	TakeAll() ([]Suppressed, error)
+}
+
+type Suppressed struct {
+	SubscriptionKey string           `json:"subscription_key"`
+	Message         model.Message    `json:"message"` //latest suppressed duplicate
+	Repetition      model.Repetition `json:"repetition"`
+	Expires         time.Time        `json:"expires"`
+}
+
+// New creates the store selected by config.DistinctStore, defaults to StoreMemory
+func New(ctx context.Context, wg *sync.WaitGroup, config configuration.Config) (Store, error) {
+	switch config.DistinctStore {
+	case "", "-", StoreMemory:
+		return NewMemory(), nil
+	case StoreFile:
+		if config.DataDir == "" || config.DataDir == "-" {
+			return nil, errors.New("distinct_store file needs a data_dir")
+		}
+		return NewFile(ctx, wg, filepath.Join(config.DataDir, "distinct"))
+	case StoreRedis:
+		if config.DistinctRedisAddr == "" || config.DistinctRedisAddr == "-" {
+			return nil, errors.New("distinct_store redis needs a distinct_redis_addr")
+		}
+		return NewRedis(config.DistinctRedisAddr, config.DistinctRedisPassword, config.DistinctRedisDb, config.DistinctRedisPrefix), nil
+	default:
+		return nil, fmt.Errorf("unknown distinct_store %#v", config.DistinctStore)
+	}
+}
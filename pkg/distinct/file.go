@@ -0,0 +1,180 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distinct
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/kv"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+// cleanupInterval is the interval in which expired windows and counts are removed from the File store
+const cleanupInterval = 10 * time.Minute
+
+// NewFile creates a store persisted in dir, so that active time windows and counts survive restarts
+func NewFile(ctx context.Context, wg *sync.WaitGroup, dir string) (*File, error) {
+	windows, err := kv.NewFile(filepath.Join(dir, "windows"))
+	if err != nil {
+		return nil, err
+	}
+	suppressed, err := kv.NewFile(filepath.Join(dir, "suppressed"))
+	if err != nil {
+		return nil, err
+	}
+	result := &File{windows: windows, suppressed: suppressed}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(cleanupInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				result.cleanup()
+			}
+		}
+	}()
+	return result, nil
+}
+
+type File struct {
+	mux        sync.Mutex //serializes the read and update of windows and suppressed entries
+	windows    kv.Store   //distinct key -> window
+	suppressed kv.Store   //distinct key -> Suppressed
+}
+
+type window struct {
+	Expires time.Time `json:"expires"`
+}
+
+func (this *File) Mark(key string, duration time.Duration) (isNew bool, repetition *model.Repetition, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	current := window{}
+	found, err := this.windows.Get(key, &current)
+	if err != nil {
+		return false, nil, err
+	}
+	if found && time.Now().Before(current.Expires) {
+		return false, nil, nil
+	}
+	err = this.windows.Set(key, window{Expires: time.Now().Add(duration)})
+	if err != nil {
+		return false, nil, err
+	}
+	repetition, err = this.take(key)
+	return true, repetition, err
+}
+
+func (this *File) Suppress(key string, subscriptionKey string, message model.Message) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	entry := Suppressed{}
+	found, err := this.suppressed.Get(key, &entry)
+	if err != nil {
+		return err
+	}
+	if !found || time.Now().After(entry.Expires) {
+		entry = Suppressed{Repetition: model.Repetition{Since: time.Now()}}
+	}
+	entry.SubscriptionKey = subscriptionKey
+	entry.Message = message
+	entry.Repetition.Count++
+	entry.Expires = time.Now().Add(SuppressedRetention)
+	return this.suppressed.Set(key, entry)
+}
+
+func (this *File) take(key string) (*model.Repetition, error) {
+	entry := Suppressed{}
+	found, err := this.suppressed.Get(key, &entry)
+	if err != nil || !found {
+		return nil, err
+	}
+	err = this.suppressed.Delete(key)
+	if err != nil {
+		return nil, err
+	}
+	if time.Now().After(entry.Expires) {
+		return nil, nil
+	}
+	return &entry.Repetition, nil
+}
+
+func (this *File) TakeAll() (result []Suppressed, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	keys, err := this.suppressed.Keys()
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		entry := Suppressed{}
+		found, err := this.suppressed.Get(key, &entry)
+		if err != nil {
+			return result, err
+		}
+		err = this.suppressed.Delete(key)
+		if err != nil {
+			return result, err
+		}
+		if found && time.Now().Before(entry.Expires) {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+func (this *File) cleanup() {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	now := time.Now()
+	keys, err := this.windows.Keys()
+	if err != nil {
+		slog.Error("unable to list distinct windows", "error", err)
+	}
+	for _, key := range keys {
+		current := window{}
+		found, err := this.windows.Get(key, &current)
+		if err == nil && found && now.After(current.Expires) {
+			err = this.windows.Delete(key)
+		}
+		if err != nil {
+			slog.Error("unable to cleanup distinct window", "key", key, "error", err)
+		}
+	}
+	keys, err = this.suppressed.Keys()
+	if err != nil {
+		slog.Error("unable to list suppressed duplicates", "error", err)
+	}
+	for _, key := range keys {
+		entry := Suppressed{}
+		found, err := this.suppressed.Get(key, &entry)
+		if err == nil && found && now.After(entry.Expires) {
+			err = this.suppressed.Delete(key)
+		}
+		if err != nil {
+			slog.Error("unable to cleanup suppressed duplicates", "key", key, "error", err)
+		}
+	}
+}
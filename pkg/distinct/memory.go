@@ -0,0 +1,83 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distinct
+
+import (
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+	"github.com/patrickmn/go-cache"
+)
+
+func NewMemory() *Memory {
+	return &Memory{
+		windows:    cache.New(5*time.Minute, 1*time.Minute),
+		suppressed: cache.New(SuppressedRetention, 10*time.Minute),
+	}
+}
+
+// Memory keeps the state in go-cache instances, it is lost on restart
+type Memory struct {
+	mux        sync.Mutex //serializes the read and update of suppressed entries
+	windows    *cache.Cache
+	suppressed *cache.Cache //distinct key -> Suppressed
+}
+
+func (this *Memory) Mark(key string, window time.Duration) (isNew bool, repetition *model.Repetition, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if this.windows.Add(key, true, window) != nil {
+		return false, nil, nil
+	}
+	return true, this.take(key), nil
+}
+
+func (this *Memory) Suppress(key string, subscriptionKey string, message model.Message) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	entry := Suppressed{Repetition: model.Repetition{Since: time.Now()}}
+	if value, found := this.suppressed.Get(key); found {
+		entry = value.(Suppressed)
+	}
+	entry.SubscriptionKey = subscriptionKey
+	entry.Message = message
+	entry.Repetition.Count++
+	entry.Expires = time.Now().Add(SuppressedRetention)
+	this.suppressed.Set(key, entry, SuppressedRetention)
+	return nil
+}
+
+func (this *Memory) take(key string) *model.Repetition {
+	value, found := this.suppressed.Get(key)
+	if !found {
+		return nil
+	}
+	this.suppressed.Delete(key)
+	repetition := value.(Suppressed).Repetition
+	return &repetition
+}
+
+func (this *Memory) TakeAll() (result []Suppressed, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	for key, item := range this.suppressed.Items() {
+		this.suppressed.Delete(key)
+		result = append(result, item.Object.(Suppressed))
+	}
+	return result, nil
+}
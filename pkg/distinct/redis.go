@@ -0,0 +1,375 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distinct
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+const DefaultRedisPrefix = "developer-notifications:"
+
+const redisTimeout = 5 * time.Second
+
+const redisScanCount = 100
+
+// NewRedis creates a store shared by multiple broker replicas. Time windows are started with SET NX,
+// so that only one replica sends a message; counts are updated in MULTI/EXEC transactions.
+func NewRedis(addr string, password string, db int, prefix string) *Redis {
+	if prefix == "" || prefix == "-" {
+		prefix = DefaultRedisPrefix
+	}
+	return &Redis{addr: addr, password: password, db: db, prefix: prefix}
+}
+
+// Redis uses a single connection, which is reestablished after network errors
+type Redis struct {
+	addr     string
+	password string
+	db       int
+	prefix   string
+	mux      sync.Mutex
+	conn     net.Conn
+	reader   *bufio.Reader
+}
+
+// RedisError is an error reply of the server
+type RedisError string
+
+func (this RedisError) Error() string {
+	return "redis: " + string(this)
+}
+
+type suppressedInfo struct {
+	SubscriptionKey string        `json:"subscription_key"`
+	Message         model.Message `json:"message"`
+}
+
+func (this *Redis) windowKey(key string) string {
+	return this.prefix + "window:" + key
+}
+
+func (this *Redis) countKey(key string) string {
+	return this.prefix + "count:" + key
+}
+
+func (this *Redis) sinceKey(key string) string {
+	return this.prefix + "since:" + key
+}
+
+func (this *Redis) infoKey(key string) string {
+	return this.prefix + "info:" + key
+}
+
+// Mark reads the count in the same transaction, in which the window is started. Duplicates counted by other replicas
+// after this transaction belong to the new window, so the count is decremented by the read value instead of deleted.
+func (this *Redis) Mark(key string, window time.Duration) (isNew bool, repetition *model.Repetition, err error) {
+	ms := strconv.FormatInt(max(window.Milliseconds(), 1), 10)
+	replies, err := this.transaction(
+		[]string{"SET", this.windowKey(key), "1", "NX", "PX", ms},
+		[]string{"GET", this.countKey(key)},
+		[]string{"GET", this.sinceKey(key)},
+	)
+	if err != nil {
+		return false, nil, err
+	}
+	if replies[0] == nil {
+		return false, nil, nil
+	}
+	repetition, err = parseRepetition(replies[1], replies[2])
+	if err != nil || repetition == nil {
+		return true, nil, err
+	}
+	replies, err = this.do([]string{"DECRBY", this.countKey(key), strconv.Itoa(repetition.Count)})
+	if err != nil {
+		return true, repetition, err
+	}
+	if remaining, _ := replies[0].(int64); remaining <= 0 {
+		_, err = this.do([]string{"DEL", this.countKey(key), this.sinceKey(key), this.infoKey(key)})
+	} else {
+		//approximation: the first duplicate of the new window has been suppressed within the last moments
+		_, err = this.do([]string{"SET", this.sinceKey(key), time.Now().Format(time.RFC3339Nano), "KEEPTTL"})
+	}
+	return true, repetition, err
+}
+
+func parseRepetition(count any, since any) (*model.Repetition, error) {
+	countValue, ok := count.(string)
+	if !ok {
+		return nil, nil
+	}
+	result := &model.Repetition{Since: time.Now()}
+	var err error
+	result.Count, err = strconv.Atoi(countValue)
+	if err != nil {
+		return nil, err
+	}
+	if sinceValue, ok := since.(string); ok {
+		result.Since, err = time.Parse(time.RFC3339Nano, sinceValue)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (this *Redis) Suppress(key string, subscriptionKey string, message model.Message) error {
+	info, err := json.Marshal(suppressedInfo{SubscriptionKey: subscriptionKey, Message: message})
+	if err != nil {
+		return err
+	}
+	retention := strconv.FormatInt(SuppressedRetention.Milliseconds(), 10)
+	_, err = this.transaction(
+		[]string{"INCR", this.countKey(key)},
+		[]string{"SET", this.sinceKey(key), time.Now().Format(time.RFC3339Nano), "NX"},
+		[]string{"SET", this.infoKey(key), string(info)},
+		[]string{"PEXPIRE", this.countKey(key), retention},
+		[]string{"PEXPIRE", this.sinceKey(key), retention},
+		[]string{"PEXPIRE", this.infoKey(key), retention},
+	)
+	return err
+}
+
+// TakeAll iterates the counts with SCAN, which does not block a redis shared with other services like KEYS
+func (this *Redis) TakeAll() (result []Suppressed, err error) {
+	keys, err := this.scan(escapeGlob(this.countKey("")) + "*")
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		entry, found, err := this.take(strings.TrimPrefix(key, this.countKey("")))
+		if err != nil {
+			return result, err
+		}
+		if found {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+// scan returns the keys matching pattern; keys may be returned more than once
+func (this *Redis) scan(pattern string) (result []string, err error) {
+	cursor := "0"
+	for {
+		replies, err := this.do([]string{"SCAN", cursor, "MATCH", pattern, "COUNT", strconv.Itoa(redisScanCount)})
+		if err != nil {
+			return nil, err
+		}
+		reply, ok := replies[0].([]any)
+		if !ok || len(reply) != 2 {
+			return nil, errors.New("redis: unexpected scan result")
+		}
+		cursor = fmt.Sprint(reply[0])
+		keys, _ := reply[1].([]any)
+		for _, key := range keys {
+			result = append(result, fmt.Sprint(key))
+		}
+		if cursor == "0" {
+			return result, nil
+		}
+	}
+}
+
+func (this *Redis) take(key string) (result Suppressed, found bool, err error) {
+	replies, err := this.transaction(
+		[]string{"GET", this.countKey(key)},
+		[]string{"GET", this.sinceKey(key)},
+		[]string{"GET", this.infoKey(key)},
+		[]string{"DEL", this.countKey(key), this.sinceKey(key), this.infoKey(key)},
+	)
+	if err != nil {
+		return result, false, err
+	}
+	repetition, err := parseRepetition(replies[0], replies[1])
+	if err != nil || repetition == nil {
+		return result, false, err
+	}
+	result.Repetition = *repetition
+	if info, ok := replies[2].(string); ok {
+		infoValue := suppressedInfo{}
+		err = json.Unmarshal([]byte(info), &infoValue)
+		if err != nil {
+			return result, false, err
+		}
+		result.SubscriptionKey = infoValue.SubscriptionKey
+		result.Message = infoValue.Message
+	}
+	return result, true, nil
+}
+
+// transaction executes the commands in MULTI/EXEC and returns their replies
+func (this *Redis) transaction(commands ...[]string) ([]any, error) {
+	commands = append(append([][]string{{"MULTI"}}, commands...), []string{"EXEC"})
+	replies, err := this.do(commands...)
+	if err != nil {
+		return nil, err
+	}
+	result, ok := replies[len(replies)-1].([]any)
+	if !ok || len(result) != len(commands)-2 {
+		return nil, errors.New("redis: unexpected transaction result")
+	}
+	for _, reply := range result {
+		if replyErr, ok := reply.(RedisError); ok {
+			return nil, replyErr
+		}
+	}
+	return result, nil
+}
+
+// do sends the commands as pipeline and returns one reply per command;
+// replies are nil, string, int64, []any or RedisError
+func (this *Redis) do(commands ...[]string) (replies []any, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if this.conn == nil {
+		err = this.connect()
+		if err != nil {
+			return nil, err
+		}
+	}
+	replies, err = this.exchange(commands)
+	if err != nil {
+		_ = this.conn.Close()
+		this.conn = nil
+		return nil, err
+	}
+	for _, reply := range replies {
+		if replyErr, ok := reply.(RedisError); ok {
+			return nil, replyErr
+		}
+	}
+	return replies, nil
+}
+
+func (this *Redis) connect() error {
+	conn, err := net.DialTimeout("tcp", this.addr, redisTimeout)
+	if err != nil {
+		return err
+	}
+	this.conn = conn
+	this.reader = bufio.NewReader(conn)
+	commands := [][]string{}
+	if this.password != "" && this.password != "-" {
+		commands = append(commands, []string{"AUTH", this.password})
+	}
+	if this.db != 0 {
+		commands = append(commands, []string{"SELECT", strconv.Itoa(this.db)})
+	}
+	if len(commands) == 0 {
+		return nil
+	}
+	replies, err := this.exchange(commands)
+	if err == nil {
+		for _, reply := range replies {
+			if replyErr, ok := reply.(RedisError); ok {
+				err = replyErr
+			}
+		}
+	}
+	if err != nil {
+		_ = conn.Close()
+		this.conn = nil
+	}
+	return err
+}
+
+func (this *Redis) exchange(commands [][]string) (replies []any, err error) {
+	err = this.conn.SetDeadline(time.Now().Add(redisTimeout))
+	if err != nil {
+		return nil, err
+	}
+	buf := strings.Builder{}
+	for _, command := range commands {
+		buf.WriteString("*" + strconv.Itoa(len(command)) + "\r\n")
+		for _, arg := range command {
+			buf.WriteString("$" + strconv.Itoa(len(arg)) + "\r\n" + arg + "\r\n")
+		}
+	}
+	_, err = io.WriteString(this.conn, buf.String())
+	if err != nil {
+		return nil, err
+	}
+	for range commands {
+		reply, err := ReadRespValue(this.reader)
+		if err != nil {
+			return nil, err
+		}
+		replies = append(replies, reply)
+	}
+	return replies, nil
+}
+
+// ReadRespValue reads a value of the redis serialization protocol (RESP2)
+func ReadRespValue(reader *bufio.Reader) (any, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if len(line) == 0 {
+		return nil, errors.New("redis: empty reply")
+	}
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return RedisError(line[1:]), nil
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		length, err := strconv.Atoi(line[1:])
+		if err != nil || length < 0 {
+			return nil, err
+		}
+		b := make([]byte, length+2)
+		_, err = io.ReadFull(reader, b)
+		if err != nil {
+			return nil, err
+		}
+		return string(b[:length]), nil
+	case '*':
+		length, err := strconv.Atoi(line[1:])
+		if err != nil || length < 0 {
+			return nil, err
+		}
+		result := make([]any, length)
+		for i := range result {
+			result[i], err = ReadRespValue(reader)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("redis: unknown reply type %q", line[0])
+	}
+}
+
+func escapeGlob(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`).Replace(value)
+}
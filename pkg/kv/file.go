@@ -0,0 +1,126 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kv
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const fileExt = ".json"
+
+// NewFile creates a store that saves each value as json file in dir
+func NewFile(dir string) (*File, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, err
+	}
+	return &File{dir: dir}, nil
+}
+
+type File struct {
+	mux sync.RWMutex
+	dir string
+}
+
+func (this *File) path(key string) string {
+	return filepath.Join(this.dir, url.PathEscape(key)+fileExt)
+}
+
+func (this *File) Get(key string, value any) (found bool, err error) {
+	this.mux.RLock()
+	defer this.mux.RUnlock()
+	b, err := os.ReadFile(this.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, json.Unmarshal(b, value)
+}
+
+// Set writes to a temporary file and renames it, so that a crash never leaves a partially written value
+func (this *File) Set(key string, value any) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	temp, err := os.CreateTemp(this.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	_, err = temp.Write(b)
+	if err == nil {
+		err = temp.Sync()
+	}
+	closeErr := temp.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(temp.Name())
+		return err
+	}
+	err = os.Rename(temp.Name(), this.path(key))
+	if err != nil {
+		_ = os.Remove(temp.Name())
+	}
+	return err
+}
+
+func (this *File) Delete(key string) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	err := os.Remove(this.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (this *File) Keys() ([]string, error) {
+	this.mux.RLock()
+	defer this.mux.RUnlock()
+	entries, err := os.ReadDir(this.dir)
+	if err != nil {
+		return nil, err
+	}
+	result := []string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, fileExt) {
+			continue
+		}
+		key, err := url.PathUnescape(strings.TrimSuffix(name, fileExt))
+		if err != nil {
+			continue
+		}
+		result = append(result, key)
+	}
+	slices.Sort(result)
+	return result, nil
+}
@@ -0,0 +1,37 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kv
+
+import (
+	"path/filepath"
+)
+
+// Store is a minimal json document store used to persist state like api managed subscriptions.
+type Store interface {
+	Get(key string, value any) (found bool, err error)
+	Set(key string, value any) error
+	Delete(key string) error
+	Keys() ([]string, error) //sorted
+}
+
+// New returns a file based store in dataDir/name, or an in-memory store if dataDir is empty or "-"
+func New(dataDir string, name string) (Store, error) {
+	if dataDir == "" || dataDir == "-" {
+		return NewMemory(), nil
+	}
+	return NewFile(filepath.Join(dataDir, name))
+}
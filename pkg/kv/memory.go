@@ -0,0 +1,72 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kv
+
+import (
+	"encoding/json"
+	"slices"
+	"sync"
+)
+
+func NewMemory() *Memory {
+	return &Memory{values: map[string][]byte{}}
+}
+
+// Memory stores json encoded values, so that callers never share references with the store
+type Memory struct {
+	mux    sync.RWMutex
+	values map[string][]byte
+}
+
+func (this *Memory) Get(key string, value any) (found bool, err error) {
+	this.mux.RLock()
+	b, found := this.values[key]
+	this.mux.RUnlock()
+	if !found {
+		return false, nil
+	}
+	return true, json.Unmarshal(b, value)
+}
+
+func (this *Memory) Set(key string, value any) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.values[key] = b
+	return nil
+}
+
+func (this *Memory) Delete(key string) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	delete(this.values, key)
+	return nil
+}
+
+func (this *Memory) Keys() ([]string, error) {
+	this.mux.RLock()
+	defer this.mux.RUnlock()
+	result := make([]string, 0, len(this.values))
+	for key := range this.values {
+		result = append(result, key)
+	}
+	slices.Sort(result)
+	return result, nil
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/cron"
+)
+
+const DeliveryModeImmediate = "immediate" //default
+const DeliveryModeDigest = "digest"       //matching messages are buffered and sent as one summary per digest_interval or digest_schedule
+
+// Digest buffers the messages of a digest subscription, grouped by sender and title
+type Digest struct {
+	SubscriptionKey string        `json:"subscription_key"`
+	Created         time.Time     `json:"created"`
+	Due             time.Time     `json:"due"`
+	Groups          []DigestGroup `json:"groups"`
+}
+
+type DigestGroup struct {
+	Sender   string    `json:"sender"`
+	Title    string    `json:"title"`
+	Count    int       `json:"count"`
+	First    time.Time `json:"first"`
+	Last     time.Time `json:"last"`
+	Severity Severity  `json:"severity"` //highest severity of the grouped messages
+	Body     string    `json:"body"`     //body of the latest message
+}
+
+// Message returns the latest message of the group; tags and labels are not kept by digests
+func (this *DigestGroup) Message() Message {
+	return Message{Sender: this.Sender, Title: this.Title, Body: this.Body, Severity: this.Severity}
+}
+
+// Add counts the message in the group of its sender and title
+func (this *Digest) Add(message Message, now time.Time) {
+	severity := message.GetSeverity()
+	for i, group := range this.Groups {
+		if group.Sender == message.Sender && group.Title == message.Title {
+			group.Count++
+			group.Last = now
+			group.Body = message.Body
+			if severity.Level() > group.Severity.Level() {
+				group.Severity = severity
+			}
+			this.Groups[i] = group
+			return
+		}
+	}
+	this.Groups = append(this.Groups, DigestGroup{
+		Sender:   message.Sender,
+		Title:    message.Title,
+		Count:    1,
+		First:    now,
+		Last:     now,
+		Severity: severity,
+		Body:     message.Body,
+	})
+}
+
+// IsDigest returns true if messages of the subscription are buffered and sent as digest
+func (this *Subscription) IsDigest() bool {
+	return this.DeliveryMode == DeliveryModeDigest
+}
+
+// NextDigest returns the time at which a digest started at t is due
+func (this *Subscription) NextDigest(t time.Time) time.Time {
+	if this.digestSchedule != nil {
+		return this.digestSchedule.Next(t)
+	}
+	return t.Add(this.digestInterval)
+}
+
+func (this *Subscription) prepareDigest() (err error) {
+	switch this.DeliveryMode {
+	case "", DeliveryModeImmediate:
+		if this.DigestInterval != "" || this.DigestSchedule != "" {
+			return fmt.Errorf("digest_interval and digest_schedule of subscription %v need delivery_mode %v", this.Key, DeliveryModeDigest)
+		}
+		return nil
+	case DeliveryModeDigest:
+	default:
+		return fmt.Errorf("unknown delivery_mode %#v in subscription %v", this.DeliveryMode, this.Key)
+	}
+	switch {
+	case this.DigestInterval != "" && this.DigestSchedule != "":
+		return fmt.Errorf("subscription %v may only use one of digest_interval and digest_schedule", this.Key)
+	case this.DigestInterval != "":
+		this.digestInterval, err = time.ParseDuration(this.DigestInterval)
+		if err == nil && this.digestInterval <= 0 {
+			err = errors.New("must be positive")
+		}
+		if err != nil {
+			return fmt.Errorf("invalid digest_interval in subscription %v: %w", this.Key, err)
+		}
+	case this.DigestSchedule != "":
+		this.digestSchedule, err = cron.Parse(this.DigestSchedule)
+		if err != nil {
+			return fmt.Errorf("invalid digest_schedule in subscription %v: %w", this.Key, err)
+		}
+	default:
+		return fmt.Errorf("subscription %v with delivery_mode %v needs a digest_interval or digest_schedule", this.Key, DeliveryModeDigest)
+	}
+	return nil
+}
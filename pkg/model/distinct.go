@@ -0,0 +1,85 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// fields usable in Subscription.DistinctFields and Subscription.GroupBy
+const DistinctFieldSender = "sender"
+const DistinctFieldTitle = "title"
+const DistinctFieldBody = "body"
+const DistinctFieldTags = "tags"
+const DistinctFieldSeverity = "severity"
+const DistinctFieldLabels = "labels"      //all labels
+const DistinctFieldLabelPrefix = "label:" //a single label, e.g. "label:environment"
+
+var DistinctFields = []string{DistinctFieldSender, DistinctFieldTitle, DistinctFieldBody, DistinctFieldTags, DistinctFieldSeverity, DistinctFieldLabels}
+
+// BodyReplacement normalizes the message body before the distinct key is computed,
+// e.g. {"pattern": "\\d{4}-\\d{2}-\\d{2}T[\\d:.]+Z?", "replacement": "<time>"}
+type BodyReplacement struct {
+	Pattern     string         `json:"pattern"` //go regexp syntax
+	Replacement string         `json:"replacement"`
+	pattern     *regexp.Regexp //compiled Pattern, set by Subscription.Prepare()
+}
+
+// NormalizeBody applies the DistinctBodyReplacements of the subscription in order
+func (this *Subscription) NormalizeBody(body string) string {
+	for _, replacement := range this.DistinctBodyReplacements {
+		pattern := replacement.pattern
+		if pattern == nil {
+			var err error
+			pattern, err = regexp.Compile(replacement.Pattern)
+			if err != nil {
+				slog.Error("invalid distinct body replacement pattern", "pattern", replacement.Pattern, "error", err)
+				continue
+			}
+		}
+		body = pattern.ReplaceAllString(body, replacement.Replacement)
+	}
+	return body
+}
+
+func isMessageField(field string) bool {
+	return slices.Contains(DistinctFields, field) || (strings.HasPrefix(field, DistinctFieldLabelPrefix) && len(field) > len(DistinctFieldLabelPrefix))
+}
+
+func (this *Subscription) prepareDistinct() error {
+	for _, field := range this.DistinctFields {
+		if !isMessageField(field) {
+			return fmt.Errorf("unknown distinct field %#v in subscription %v", field, this.Key)
+		}
+	}
+	replacements, err := prepareCopy(this.DistinctBodyReplacements, func(replacement *BodyReplacement) (err error) {
+		replacement.pattern, err = regexp.Compile(replacement.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid distinct body replacement pattern %#v in subscription %v: %w", replacement.Pattern, this.Key, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	this.DistinctBodyReplacements = replacements
+	return nil
+}
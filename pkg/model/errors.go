@@ -1,5 +1,5 @@
 /*
- * Copyright 2023 InfAI (CC SES)
+ * Copyright 2026 InfAI (CC SES)
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
@@ -14,15 +14,11 @@
  * limitations under the License.
  */
 
-package broker
+package model
 
-import "time"
+import "errors"
 
-func (this *Broker) existsInCache(key string) (found bool) {
-	_, found = this.cache.Get(key)
-	return
-}
-
-func (this *Broker) addToCache(key string, duration time.Duration) {
-	this.cache.Set(key, true, duration)
-}
+var ErrNotFound = errors.New("not found")
+var ErrAlreadyExists = errors.New("already exists")
+var ErrReadOnly = errors.New("read only")
+var ErrInvalid = errors.New("invalid")
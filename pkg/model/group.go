@@ -0,0 +1,98 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const DefaultGroupWait = 30 * time.Second
+const DefaultGroupInterval = 5 * time.Minute
+
+// MaxGroupMessages limits the messages kept per AlertGroup notification; further messages are only counted
+const MaxGroupMessages = 100
+
+// AlertGroup collects the messages of a grouped subscription with equal Subscription.GroupBy values
+type AlertGroup struct {
+	Key             string            `json:"key"`
+	SubscriptionKey string            `json:"subscription_key"`
+	Values          map[string]string `json:"values"`    //GroupBy field -> value of the group
+	Messages        []Message         `json:"messages"`  //pending messages, the first MaxGroupMessages
+	Count           int               `json:"count"`     //number of pending messages, including those not in Messages
+	Due             time.Time         `json:"due"`       //time at which the pending messages are sent
+	LastSent        time.Time         `json:"last_sent"` //zero if no notification has been sent for the group
+}
+
+// IsGrouped returns true if messages of the subscription are grouped by GroupBy
+func (this *Subscription) IsGrouped() bool {
+	return len(this.GroupBy) > 0
+}
+
+// GetGroupWait returns the parsed GroupWait or DefaultGroupWait
+func (this *Subscription) GetGroupWait() time.Duration {
+	if this.groupWait == 0 {
+		return DefaultGroupWait
+	}
+	return this.groupWait
+}
+
+// GetGroupInterval returns the parsed GroupInterval or DefaultGroupInterval
+func (this *Subscription) GetGroupInterval() time.Duration {
+	if this.groupInterval == 0 {
+		return DefaultGroupInterval
+	}
+	return this.groupInterval
+}
+
+func (this *Subscription) prepareGroup() (err error) {
+	if !this.IsGrouped() {
+		if this.GroupWait != "" || this.GroupInterval != "" {
+			return fmt.Errorf("group_wait and group_interval of subscription %v need group_by", this.Key)
+		}
+		return nil
+	}
+	if this.IsDigest() {
+		return fmt.Errorf("subscription %v may not use group_by with delivery_mode %v", this.Key, DeliveryModeDigest)
+	}
+	for _, field := range this.GroupBy {
+		if !isMessageField(field) {
+			return fmt.Errorf("unknown group_by field %#v in subscription %v", field, this.Key)
+		}
+	}
+	this.groupWait, err = parseGroupDuration(this.GroupWait)
+	if err != nil {
+		return fmt.Errorf("invalid group_wait in subscription %v: %w", this.Key, err)
+	}
+	this.groupInterval, err = parseGroupDuration(this.GroupInterval)
+	if err != nil {
+		return fmt.Errorf("invalid group_interval in subscription %v: %w", this.Key, err)
+	}
+	return nil
+}
+
+func parseGroupDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	result, err := time.ParseDuration(value)
+	if err == nil && result <= 0 {
+		err = errors.New("must be positive")
+	}
+	return result, err
+}
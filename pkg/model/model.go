@@ -17,33 +17,90 @@
 package model
 
 import (
+	"fmt"
 	"log/slog"
+	"regexp"
 	"slices"
+	"strings"
 	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/cron"
 )
 
 type Message struct {
-	Sender string   `json:"sender"`
-	Title  string   `json:"title"`
-	Body   string   `json:"body"`
-	Tags   []string `json:"tags"`
+	Sender   string            `json:"sender"`
+	Title    string            `json:"title"`
+	Body     string            `json:"body"`
+	Tags     []string          `json:"tags"`
+	Severity Severity          `json:"severity,omitempty"` //if empty, the severity is derived from the tags (see Message.GetSeverity())
+	Labels   map[string]string `json:"labels,omitempty"`   //structured context like environment, instance or trace id
+
+	//set by the broker, if duplicates of the message have been suppressed since it was last sent
+	Repetition *Repetition `json:"repetition,omitempty"`
 }
 
 type Subscription struct {
-	Key                        string          `json:"key"` //used to identify the subscription
-	Receiver                   string          `json:"receiver"`
-	DistinctTimeWindow         string          `json:"distinct_time_window"`
-	DistinctTimeWindowDuration time.Duration   `json:"-"`
-	Filter                     []MessageFilter `json:"filter"`                   //subscription is a match if no filter is not a match
-	AdditionalReceiverInfo     string          `json:"additional_receiver_info"` //it is the receivers concern to interpret this field however it needs to
-	Disabled                   bool            `json:"disabled"`
+	Key                        string            `json:"key"` //used to identify the subscription
+	Receiver                   string            `json:"receiver"`
+	DistinctTimeWindow         string            `json:"distinct_time_window"`
+	DistinctTimeWindowDuration time.Duration     `json:"-"`
+	DistinctFields             []string          `json:"distinct_fields,omitempty"`            //message fields identifying equal messages, defaults to the whole message (see DistinctFields)
+	DistinctBodyReplacements   []BodyReplacement `json:"distinct_body_replacements,omitempty"` //applied to the body before equal messages are identified
+	Filter                     []MessageFilter   `json:"filter"`                               //subscription is a match if no filter is not a match
+	AdditionalReceiverInfo     ReceiverInfo      `json:"additional_receiver_info"`             //it is the receivers concern to interpret this field however it needs to
+	Disabled                   bool              `json:"disabled"`
+	ReadOnly                   bool              `json:"read_only"` //set for subscriptions from the config or subscription_files_dir, which can not be changed by the api
+
+	DeliveryMode   string         `json:"delivery_mode,omitempty"`   //DeliveryModeImmediate or DeliveryModeDigest
+	DigestInterval string         `json:"digest_interval,omitempty"` //e.g. "1h", digests are due this duration after their first message
+	DigestSchedule string         `json:"digest_schedule,omitempty"` //cron expression like "0 9 * * mon-fri", alternative to DigestInterval
+	digestInterval time.Duration  //parsed DigestInterval, set by Subscription.Prepare()
+	digestSchedule *cron.Schedule //parsed DigestSchedule, set by Subscription.Prepare()
+
+	GroupBy       []string      `json:"group_by,omitempty"`       //message fields like "sender" or "label:instance" (see DistinctFields); enables grouping
+	GroupWait     string        `json:"group_wait,omitempty"`     //time the first message of a group is held back for further messages, defaults to 30s
+	GroupInterval string        `json:"group_interval,omitempty"` //minimal time between notifications of a group, defaults to 5m
+	groupWait     time.Duration //parsed GroupWait, set by Subscription.Prepare()
+	groupInterval time.Duration //parsed GroupInterval, set by Subscription.Prepare()
+
+	ActiveHours        []ActiveHours       `json:"active_hours,omitempty"`         //weekly schedule; if set, the subscription is only active within these ranges
+	TimeZone           string              `json:"time_zone,omitempty"`            //time zone of the active hours like "Europe/Berlin", defaults to the local time zone
+	MaintenanceWindows []MaintenanceWindow `json:"maintenance_windows,omitempty"`  //one-off ranges in which the subscription is inactive
+	OutsideActiveHours string              `json:"outside_active_hours,omitempty"` //OutsideActiveHoursDrop or OutsideActiveHoursDefer
+	location           *time.Location      //loaded TimeZone, set by Subscription.Prepare()
+}
+
+// Delivery is a message queued for a subscription
+type Delivery struct {
+	Id           string            `json:"id"`
+	Subscription Subscription      `json:"subscription"`
+	Message      Message           `json:"message"`
+	Created      time.Time         `json:"created"`
+	NextAttempt  time.Time         `json:"next_attempt"`
+	Attempts     []DeliveryAttempt `json:"attempts"`
+}
+
+type DeliveryAttempt struct {
+	Time  time.Time `json:"time"`
+	Error string    `json:"error"`
 }
 
 type MessageFilterType string
 
 const SenderFilter MessageFilterType = "sender"
 const TagFilter MessageFilterType = "tag"
+const TitleFilter MessageFilterType = "title"
+const BodyFilter MessageFilterType = "body"
+const MinSeverityFilter MessageFilterType = "min_severity" //match if Message.GetSeverity() is at least the severity in Value
+const LabelFilter MessageFilterType = "label"              //compares Value with the label named by Key; never matches if the label is missing
+const LabelRegexFilter MessageFilterType = "label_regex"   //like LabelFilter, but always uses the RegexOperator
 
+// filter types combining the child filters in MessageFilter.Filter
+const AnyFilter MessageFilterType = "any" //match if at least one child filter matches
+const AllFilter MessageFilterType = "all" //match if every child filter matches
+const NotFilter MessageFilterType = "not" //match if no child filter matches
+
+// KnownTags are mapped to a Severity for messages without Message.Severity
 var KnownTags = struct {
 	Error        string
 	Warning      string
@@ -54,9 +111,24 @@ var KnownTags = struct {
 	Notification: "notification",
 }
 
+type MessageFilterOperator string
+
+// operators used by sender, tag, title, body and label filters to compare Value
+// defaults to EqualsOperator for sender, tag and label and to ContainsOperator for title and body
+const EqualsOperator MessageFilterOperator = "equals"
+const PrefixOperator MessageFilterOperator = "prefix"
+const ContainsOperator MessageFilterOperator = "contains"
+const GlobOperator MessageFilterOperator = "glob"   //'*' and '?' do not match '/', '**' matches everything
+const RegexOperator MessageFilterOperator = "regex" //go regexp syntax, not anchored
+
 type MessageFilter struct {
-	Type  MessageFilterType
-	Value string
+	Type       MessageFilterType     `json:"type"`
+	Key        string                `json:"key,omitempty"` //label name of label and label_regex filters
+	Value      string                `json:"value"`
+	Operator   MessageFilterOperator `json:"operator,omitempty"`
+	IgnoreCase bool                  `json:"ignore_case,omitempty"`
+	Filter     []MessageFilter       `json:"filter,omitempty"` //child filters of any, all and not
+	pattern    *regexp.Regexp        //compiled Value of glob and regex operators, set by Subscription.Prepare()
 }
 
 func (this *Subscription) Match(message Message) bool {
@@ -68,14 +140,221 @@ func (this *Subscription) Match(message Message) bool {
 	return true
 }
 
+// Prepare validates the filters and distinct settings and compiles their patterns, so that they are not compiled for every message
+func (this *Subscription) Prepare() (err error) {
+	this.Filter, err = prepareFilters(this.Filter)
+	if err != nil {
+		return fmt.Errorf("invalid filter in subscription %v: %w", this.Key, err)
+	}
+	err = this.prepareDistinct()
+	if err != nil {
+		return err
+	}
+	err = this.prepareDigest()
+	if err != nil {
+		return err
+	}
+	err = this.prepareGroup()
+	if err != nil {
+		return err
+	}
+	return this.prepareSchedule()
+}
+
+func prepareFilters(list []MessageFilter) (result []MessageFilter, err error) {
+	return prepareCopy(list, (*MessageFilter).prepare)
+}
+
+// prepareCopy applies prepare to the elements of a copy of the list,
+// because the list may be shared with other subscription instances
+func prepareCopy[T any](list []T, prepare func(*T) error) (result []T, err error) {
+	if list == nil {
+		return nil, nil
+	}
+	result = make([]T, len(list))
+	for i, element := range list {
+		err = prepare(&element)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = element
+	}
+	return result, nil
+}
+
 func (this *MessageFilter) Match(message Message) bool {
 	switch this.Type {
 	case SenderFilter:
-		return message.Sender == this.Value
+		return this.matchValue(message.Sender)
 	case TagFilter:
-		return slices.Contains(message.Tags, this.Value)
+		return slices.ContainsFunc(message.Tags, this.matchValue)
+	case TitleFilter:
+		return this.matchValue(message.Title)
+	case BodyFilter:
+		return this.matchValue(message.Body)
+	case LabelFilter, LabelRegexFilter:
+		value, ok := message.Labels[this.Key]
+		return ok && this.matchValue(value)
+	case MinSeverityFilter:
+		minimum, err := ParseSeverity(this.Value)
+		if err != nil {
+			slog.Error("invalid min_severity filter value", "value", this.Value)
+			return false
+		}
+		return message.GetSeverity().Level() >= minimum.Level()
+	case AnyFilter:
+		for _, filter := range this.Filter {
+			if filter.Match(message) {
+				return true
+			}
+		}
+		return false
+	case AllFilter:
+		for _, filter := range this.Filter {
+			if !filter.Match(message) {
+				return false
+			}
+		}
+		return true
+	case NotFilter:
+		for _, filter := range this.Filter {
+			if filter.Match(message) {
+				return false
+			}
+		}
+		return true
 	default:
 		slog.Error("unknown message filter type", "type", this.Type, "value", this.Value)
 		return false
 	}
 }
+
+func (this *MessageFilter) operator() MessageFilterOperator {
+	if this.Operator != "" {
+		return this.Operator
+	}
+	switch this.Type {
+	case LabelRegexFilter:
+		return RegexOperator
+	case TitleFilter, BodyFilter:
+		return ContainsOperator
+	default:
+		return EqualsOperator
+	}
+}
+
+func (this *MessageFilter) matchValue(value string) bool {
+	expected := this.Value
+	if this.IgnoreCase {
+		value = strings.ToLower(value)
+		expected = strings.ToLower(expected)
+	}
+	switch this.operator() {
+	case EqualsOperator:
+		return value == expected
+	case PrefixOperator:
+		return strings.HasPrefix(value, expected)
+	case ContainsOperator:
+		return strings.Contains(value, expected)
+	case GlobOperator, RegexOperator:
+		pattern := this.pattern
+		if pattern == nil {
+			var err error
+			pattern, err = this.compile()
+			if err != nil {
+				slog.Error("invalid message filter pattern", "operator", this.Operator, "value", this.Value, "error", err)
+				return false
+			}
+		}
+		return pattern.MatchString(value)
+	default:
+		slog.Error("unknown message filter operator", "operator", this.Operator, "value", this.Value)
+		return false
+	}
+}
+
+func (this *MessageFilter) compile() (*regexp.Regexp, error) {
+	flags := ""
+	if this.IgnoreCase {
+		flags = "(?i)"
+	}
+	switch this.operator() {
+	case GlobOperator:
+		return regexp.Compile(flags + globToRegex(this.Value))
+	case RegexOperator:
+		return regexp.Compile(flags + this.Value)
+	default:
+		return nil, nil
+	}
+}
+
+func globToRegex(glob string) string {
+	result := strings.Builder{}
+	result.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**"):
+			result.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			result.WriteString("[^/]*")
+		case glob[i] == '?':
+			result.WriteString("[^/]")
+		default:
+			result.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	result.WriteString("$")
+	return result.String()
+}
+
+func (this *MessageFilter) prepare() (err error) {
+	switch this.Type {
+	case SenderFilter, TagFilter, TitleFilter, BodyFilter, LabelFilter, LabelRegexFilter:
+		if len(this.Filter) > 0 {
+			return fmt.Errorf("%v filter may not contain child filters", this.Type)
+		}
+		isLabelFilter := this.Type == LabelFilter || this.Type == LabelRegexFilter
+		if isLabelFilter && this.Key == "" {
+			return fmt.Errorf("%v filter needs a key", this.Type)
+		}
+		if !isLabelFilter && this.Key != "" {
+			return fmt.Errorf("%v filter does not use a key", this.Type)
+		}
+		if this.Type == LabelRegexFilter && this.operator() != RegexOperator {
+			return fmt.Errorf("%v filter only supports the %v operator", this.Type, RegexOperator)
+		}
+		switch this.operator() {
+		case EqualsOperator, PrefixOperator, ContainsOperator, GlobOperator, RegexOperator:
+		default:
+			return fmt.Errorf("unknown %v filter operator %#v", this.Type, this.Operator)
+		}
+		this.pattern, err = this.compile()
+		if err != nil {
+			return fmt.Errorf("invalid %v filter pattern %#v: %w", this.Type, this.Value, err)
+		}
+	case MinSeverityFilter:
+		if len(this.Filter) > 0 || this.Operator != "" || this.IgnoreCase || this.Key != "" {
+			return fmt.Errorf("%v filter does not use child filters or operators", this.Type)
+		}
+		severity, err := ParseSeverity(this.Value)
+		if err != nil {
+			return fmt.Errorf("invalid %v filter value %#v", this.Type, this.Value)
+		}
+		this.Value = string(severity)
+	case AnyFilter, AllFilter, NotFilter:
+		if len(this.Filter) == 0 {
+			return fmt.Errorf("%v filter needs at least one child filter", this.Type)
+		}
+		if this.Value != "" || this.Operator != "" || this.IgnoreCase || this.Key != "" {
+			return fmt.Errorf("%v filter does not use a key, value or operator", this.Type)
+		}
+		this.Filter, err = prepareFilters(this.Filter)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown message filter type %#v", this.Type)
+	}
+	return nil
+}
@@ -0,0 +1,337 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestSubscription_Match(t *testing.T) {
+	message := Message{
+		Sender: "github.com/SENERGY-Platform/developer-notifications",
+		Title:  "Test Message",
+		Body:   "connection refused",
+		Tags:   []string{KnownTags.Error, "critical"},
+		Labels: map[string]string{"environment": "prod", "device_id": "urn:infai:ses:device:4711"},
+	}
+
+	tests := []struct {
+		name     string
+		filter   string
+		expected bool
+	}{
+		{name: "empty", filter: `[]`, expected: true},
+		{name: "flat match", filter: `[{"type": "tag", "value": "error"}, {"type": "sender", "value": "github.com/SENERGY-Platform/developer-notifications"}]`, expected: true},
+		{name: "flat mismatch", filter: `[{"type": "tag", "value": "error"}, {"type": "sender", "value": "other"}]`, expected: false},
+		{name: "any", filter: `[{"type": "any", "filter": [{"type": "tag", "value": "warning"}, {"type": "tag", "value": "critical"}]}]`, expected: true},
+		{name: "any mismatch", filter: `[{"type": "any", "filter": [{"type": "tag", "value": "warning"}, {"type": "tag", "value": "notification"}]}]`, expected: false},
+		{name: "all", filter: `[{"type": "all", "filter": [{"type": "tag", "value": "error"}, {"type": "tag", "value": "critical"}]}]`, expected: true},
+		{name: "all mismatch", filter: `[{"type": "all", "filter": [{"type": "tag", "value": "error"}, {"type": "tag", "value": "warning"}]}]`, expected: false},
+		{name: "not", filter: `[{"type": "not", "filter": [{"type": "sender", "value": "other"}]}]`, expected: true},
+		{name: "not mismatch", filter: `[{"type": "not", "filter": [{"type": "tag", "value": "error"}]}]`, expected: false},
+		{name: "not multiple", filter: `[{"type": "not", "filter": [{"type": "sender", "value": "other"}, {"type": "tag", "value": "warning"}]}]`, expected: true},
+		{name: "not multiple mismatch", filter: `[{"type": "not", "filter": [{"type": "sender", "value": "other"}, {"type": "sender", "value": "github.com/SENERGY-Platform/developer-notifications"}]}]`, expected: false},
+		{name: "sender prefix", filter: `[{"type": "sender", "operator": "prefix", "value": "github.com/SENERGY-Platform/"}]`, expected: true},
+		{name: "sender prefix mismatch", filter: `[{"type": "sender", "operator": "prefix", "value": "github.com/other/"}]`, expected: false},
+		{name: "sender glob", filter: `[{"type": "sender", "operator": "glob", "value": "github.com/SENERGY-Platform/*"}]`, expected: true},
+		{name: "sender glob mismatch", filter: `[{"type": "sender", "operator": "glob", "value": "github.com/*"}]`, expected: false},
+		{name: "sender double star glob", filter: `[{"type": "sender", "operator": "glob", "value": "github.com/**"}]`, expected: true},
+		{name: "sender regex", filter: `[{"type": "sender", "operator": "regex", "value": "^github\\.com/SENERGY-Platform/(developer|device)-.*$"}]`, expected: true},
+		{name: "tag regex", filter: `[{"type": "tag", "operator": "regex", "value": "^crit"}]`, expected: true},
+		{name: "tag glob mismatch", filter: `[{"type": "tag", "operator": "glob", "value": "warn*"}]`, expected: false},
+		{name: "title contains", filter: `[{"type": "title", "value": "Message"}]`, expected: true},
+		{name: "title contains case mismatch", filter: `[{"type": "title", "value": "message"}]`, expected: false},
+		{name: "title contains ignore case", filter: `[{"type": "title", "value": "message", "ignore_case": true}]`, expected: true},
+		{name: "title equals", filter: `[{"type": "title", "operator": "equals", "value": "Test"}]`, expected: false},
+		{name: "body contains", filter: `[{"type": "body", "value": "refused"}]`, expected: true},
+		{name: "body contains mismatch", filter: `[{"type": "body", "value": "timeout"}]`, expected: false},
+		{name: "body regex", filter: `[{"type": "body", "operator": "regex", "value": "(timeout|refused)$"}]`, expected: true},
+		{name: "body regex ignore case", filter: `[{"type": "body", "operator": "regex", "value": "^CONNECTION", "ignore_case": true}]`, expected: true},
+		{name: "sender equals ignore case", filter: `[{"type": "sender", "value": "GITHUB.COM/senergy-platform/developer-notifications", "ignore_case": true}]`, expected: true},
+		{name: "min severity from legacy tags", filter: `[{"type": "min_severity", "value": "critical"}]`, expected: true},
+		{name: "min severity lower", filter: `[{"type": "min_severity", "value": "Warning"}]`, expected: true},
+		{name: "not min severity", filter: `[{"type": "not", "filter": [{"type": "min_severity", "value": "error"}]}]`, expected: false},
+		{name: "label", filter: `[{"type": "label", "key": "environment", "value": "prod"}]`, expected: true},
+		{name: "label mismatch", filter: `[{"type": "label", "key": "environment", "value": "dev"}]`, expected: false},
+		{name: "label missing", filter: `[{"type": "label", "key": "trace_id", "value": ""}]`, expected: false},
+		{name: "label prefix", filter: `[{"type": "label", "key": "device_id", "operator": "prefix", "value": "urn:infai:ses:device:"}]`, expected: true},
+		{name: "label regex", filter: `[{"type": "label_regex", "key": "device_id", "value": ":\\d+$"}]`, expected: true},
+		{name: "label regex mismatch", filter: `[{"type": "label_regex", "key": "environment", "value": "^(dev|stage)$"}]`, expected: false},
+		{name: "label regex missing", filter: `[{"type": "label_regex", "key": "trace_id", "value": ".*"}]`, expected: false},
+		{name: "nested", filter: `[{"type": "any", "filter": [{"type": "tag", "value": "warning"}, {"type": "all", "filter": [{"type": "tag", "value": "error"}, {"type": "not", "filter": [{"type": "sender", "value": "other"}]}]}]}]`, expected: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sub := Subscription{}
+			err := json.Unmarshal([]byte(test.filter), &sub.Filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = sub.Prepare()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual := sub.Match(message); actual != test.expected {
+				t.Error(actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestSubscription_Prepare(t *testing.T) {
+	invalid := []string{
+		`[{"type": "unknown", "value": "foo"}]`,
+		`[{"type": "any"}]`,
+		`[{"type": "not", "value": "foo", "filter": [{"type": "tag", "value": "error"}]}]`,
+		`[{"type": "tag", "value": "error", "filter": [{"type": "tag", "value": "error"}]}]`,
+		`[{"type": "all", "filter": [{"type": "any", "filter": []}]}]`,
+		`[{"type": "sender", "value": "foo", "operator": "unknown"}]`,
+		`[{"type": "sender", "value": "(", "operator": "regex"}]`,
+		`[{"type": "any", "operator": "regex", "filter": [{"type": "tag", "value": "error"}]}]`,
+		`[{"type": "min_severity", "value": "fatal"}]`,
+		`[{"type": "label", "value": "prod"}]`,
+		`[{"type": "label_regex", "key": "environment", "value": "prod", "operator": "equals"}]`,
+		`[{"type": "label_regex", "key": "environment", "value": "("}]`,
+		`[{"type": "sender", "key": "environment", "value": "prod"}]`,
+		`[{"type": "min_severity", "value": "error", "operator": "prefix"}]`,
+	}
+	for _, filter := range invalid {
+		sub := Subscription{}
+		err := json.Unmarshal([]byte(filter), &sub.Filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sub.Prepare() == nil {
+			t.Error("expected error for", filter)
+		}
+	}
+}
+
+func TestSubscription_PrepareGroup(t *testing.T) {
+	invalid := []string{
+		`{"group_by": ["unknown"]}`,
+		`{"group_wait": "10s"}`,
+		`{"group_by": ["sender"], "group_interval": "0s"}`,
+		`{"group_by": ["sender"], "group_wait": "soon"}`,
+		`{"group_by": ["sender"], "delivery_mode": "digest", "digest_interval": "1h"}`,
+	}
+	for _, input := range invalid {
+		sub := Subscription{}
+		err := json.Unmarshal([]byte(input), &sub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sub.Prepare() == nil {
+			t.Error("expected error for", input)
+		}
+	}
+
+	sub := Subscription{GroupBy: []string{"sender", "label:instance"}, GroupInterval: "1h"}
+	err := sub.Prepare()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sub.IsGrouped() || sub.GetGroupWait() != DefaultGroupWait || sub.GetGroupInterval() != time.Hour {
+		t.Error(sub.GetGroupWait(), sub.GetGroupInterval())
+	}
+}
+
+func TestSubscription_PrepareDigest(t *testing.T) {
+	invalid := []string{
+		`{"delivery_mode": "unknown"}`,
+		`{"delivery_mode": "digest"}`,
+		`{"delivery_mode": "digest", "digest_interval": "1h", "digest_schedule": "0 9 * * *"}`,
+		`{"delivery_mode": "digest", "digest_interval": "-1h"}`,
+		`{"delivery_mode": "digest", "digest_schedule": "0 25 * * *"}`,
+		`{"delivery_mode": "digest", "digest_schedule": "0 9 30 feb *"}`,
+		`{"digest_interval": "1h"}`,
+	}
+	for _, input := range invalid {
+		sub := Subscription{}
+		err := json.Unmarshal([]byte(input), &sub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sub.Prepare() == nil {
+			t.Error("expected error for", input)
+		}
+	}
+
+	start := time.Date(2026, 3, 6, 10, 2, 0, 0, time.UTC)
+	for input, expected := range map[string]time.Time{
+		`{"delivery_mode": "digest", "digest_interval": "1h"}`:                    start.Add(time.Hour),
+		`{"delivery_mode": "digest", "digest_schedule": "CRON_TZ=UTC 0 9 * * *"}`: time.Date(2026, 3, 7, 9, 0, 0, 0, time.UTC),
+	} {
+		sub := Subscription{}
+		err := json.Unmarshal([]byte(input), &sub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = sub.Prepare()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !sub.IsDigest() || !sub.NextDigest(start).Equal(expected) {
+			t.Error(input, sub.NextDigest(start), expected)
+		}
+	}
+}
+
+func TestMessage_GetSeverity(t *testing.T) {
+	tests := []struct {
+		message  string
+		expected Severity
+	}{
+		{message: `{}`, expected: SeverityInfo},
+		{message: `{"tags": ["foo"]}`, expected: SeverityInfo},
+		{message: `{"tags": ["notification"]}`, expected: SeverityNotice},
+		{message: `{"tags": ["notification", "warning"]}`, expected: SeverityWarning},
+		{message: `{"tags": ["Error", "debug"]}`, expected: SeverityError},
+		{message: `{"tags": ["debug"]}`, expected: SeverityDebug},
+		{message: `{"severity": "debug", "tags": ["error"]}`, expected: SeverityDebug},
+		{message: `{"severity": "CRITICAL"}`, expected: SeverityCritical},
+	}
+	for _, test := range tests {
+		message := Message{}
+		err := json.Unmarshal([]byte(test.message), &message)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := message.GetSeverity(); actual != test.expected {
+			t.Error(test.message, actual, test.expected)
+		}
+	}
+	if json.Unmarshal([]byte(`{"severity": "fatal"}`), &Message{}) == nil {
+		t.Error("expected error for unknown severity")
+	}
+	if SeverityWarning.Level() <= SeverityNotice.Level() || SeverityCritical.Level() <= SeverityError.Level() {
+		t.Error("unexpected severity order")
+	}
+}
+
+func TestReceiverInfo(t *testing.T) {
+	for _, input := range []string{`{"additional_receiver_info":"foo@example.com"}`, `{"additional_receiver_info":{"url":"http://example.com","headers":{"a":"b"}}}`} {
+		sub := Subscription{}
+		err := json.Unmarshal([]byte(input), &sub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		output, err := json.Marshal(sub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := map[string]json.RawMessage{}
+		err = json.Unmarshal(output, &result)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := input[len(`{"additional_receiver_info":`) : len(input)-1]; string(result["additional_receiver_info"]) != expected {
+			t.Error(string(result["additional_receiver_info"]), expected)
+		}
+	}
+	sub := Subscription{}
+	_ = json.Unmarshal([]byte(`{"additional_receiver_info": {"url": "http://example.com"}}`), &sub)
+	if sub.AdditionalReceiverInfo != `{"url":"http://example.com"}` {
+		t.Error(sub.AdditionalReceiverInfo)
+	}
+}
+
+func TestSilence(t *testing.T) {
+	now := time.Now()
+	silence := Silence{
+		Id:            "test",
+		Matchers:      []MessageFilter{{Type: TitleFilter, Value: "disk*", Operator: GlobOperator}},
+		Subscriptions: []string{"ops"},
+		Start:         now,
+		End:           now.Add(time.Hour),
+		Author:        "tester",
+	}
+	err := silence.Prepare()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if silence.IsActive(now.Add(-time.Second)) || !silence.IsActive(now) || silence.IsActive(now.Add(time.Hour)) {
+		t.Error("unexpected active state")
+	}
+	if !silence.IsExpired(now.Add(time.Hour)) || silence.IsExpired(now) {
+		t.Error("unexpected expired state")
+	}
+	if !silence.Match(Message{Title: "disk full"}, "ops") || silence.Match(Message{Title: "disk full"}, "dev") || silence.Match(Message{Title: "cpu"}, "ops") {
+		t.Error("unexpected match")
+	}
+}
+
+func TestSubscription_IsActiveAt(t *testing.T) {
+	invalid := []string{
+		`{"outside_active_hours": "later"}`,
+		`{"time_zone": "Nowhere/Unknown"}`,
+		`{"active_hours": [{"start": "8:00", "end": "25:00"}]}`,
+		`{"active_hours": [{"start": "08:00", "end": "08:00"}]}`,
+		`{"active_hours": [{"days": ["someday"], "start": "08:00", "end": "18:00"}]}`,
+		`{"maintenance_windows": [{"start": "2026-01-02T00:00:00Z", "end": "2026-01-01T00:00:00Z"}]}`,
+	}
+	for _, input := range invalid {
+		sub := Subscription{}
+		err := json.Unmarshal([]byte(input), &sub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sub.Prepare() == nil {
+			t.Error("expected error for", input)
+		}
+	}
+
+	sub := Subscription{}
+	err := json.Unmarshal([]byte(`{
+		"time_zone": "Europe/Berlin",
+		"active_hours": [
+			{"days": ["mon", "tue", "wed", "thu", "fri"], "start": "08:00", "end": "18:00"},
+			{"days": ["saturday"], "start": "22:00", "end": "02:00"}
+		],
+		"maintenance_windows": [{"start": "2026-10-14T10:00:00+02:00", "end": "2026-10-14T12:00:00+02:00"}]
+	}`), &sub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sub.Prepare()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := map[string]bool{
+		"2026-10-12T08:00:00+02:00": true,  //monday
+		"2026-10-12T07:59:00+02:00": false, //monday before start
+		"2026-10-12T18:00:00+02:00": false, //monday at end
+		"2026-10-12T06:30:00Z":      true,  //monday 08:30 in Berlin
+		"2026-10-14T11:00:00+02:00": false, //maintenance
+		"2026-10-14T12:00:00+02:00": true,  //maintenance ended
+		"2026-10-17T23:00:00+02:00": true,  //saturday night
+		"2026-10-18T01:59:00+02:00": true,  //sunday, range of saturday over midnight
+		"2026-10-18T02:00:00+02:00": false,
+		"2026-10-18T23:00:00+02:00": false, //sunday night
+	}
+	for input, expected := range cases {
+		value, err := time.Parse(time.RFC3339, input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sub.IsActiveAt(value) != expected {
+			t.Error(input, expected)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// ReceiverInfo may be written as json string or, for receivers with structured settings, as json object.
+// Objects are kept as compact json text, so that receivers always get a string.
+type ReceiverInfo string
+
+func (this *ReceiverInfo) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		*this = ""
+		return nil
+	}
+	if len(b) > 0 && b[0] == '"' {
+		var str string
+		err := json.Unmarshal(b, &str)
+		if err != nil {
+			return err
+		}
+		*this = ReceiverInfo(str)
+		return nil
+	}
+	buf := bytes.Buffer{}
+	err := json.Compact(&buf, b)
+	if err != nil {
+		return err
+	}
+	*this = ReceiverInfo(buf.String())
+	return nil
+}
+
+func (this ReceiverInfo) MarshalJSON() ([]byte, error) {
+	trimmed := strings.TrimSpace(string(this))
+	if strings.HasPrefix(trimmed, "{") && json.Valid([]byte(trimmed)) {
+		return []byte(trimmed), nil
+	}
+	return json.Marshal(string(this))
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// Repetition counts the duplicates of a message, which have been suppressed by the distinct time window of a subscription
+type Repetition struct {
+	Count int       `json:"count"`
+	Since time.Time `json:"since"` //time of the first suppressed duplicate
+}
+
+// String returns a summary like "repeated 437 times since 10:02", used by the receiver templates
+func (this Repetition) String() string {
+	since := this.Since.Local()
+	layout := "15:04"
+	if since.Format(time.DateOnly) != time.Now().Format(time.DateOnly) {
+		layout = "2006-01-02 15:04"
+	}
+	times := "times"
+	if this.Count == 1 {
+		times = "time"
+	}
+	return fmt.Sprintf("repeated %v %v since %v", this.Count, times, since.Format(layout))
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const OutsideActiveHoursDrop = "drop"   //default, messages outside the active hours or inside maintenance windows are dropped
+const OutsideActiveHoursDefer = "defer" //messages are held back until the subscription is active again
+
+// ActiveHours is a weekly recurring time range, e.g. {"days": ["mon", "tue", "wed", "thu", "fri"], "start": "08:00", "end": "18:00"}
+type ActiveHours struct {
+	Days  []string `json:"days,omitempty"` //"mon" to "sun", defaults to every day
+	Start string   `json:"start"`          //"15:04", inclusive
+	End   string   `json:"end"`            //exclusive; "24:00" or a time before Start extends the range over midnight
+	days  uint8    //bit set of time.Weekday, set by Subscription.Prepare()
+	start int      //minute of the day, set by Subscription.Prepare()
+	end   int      //minute of the day, set by Subscription.Prepare()
+}
+
+// MaintenanceWindow is a one-off time range in which the subscription is inactive
+type MaintenanceWindow struct {
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Comment string    `json:"comment,omitempty"`
+}
+
+// IsActiveAt returns false if t is in a maintenance window or outside the active hours of the prepared subscription
+func (this *Subscription) IsActiveAt(t time.Time) bool {
+	for _, window := range this.MaintenanceWindows {
+		if !t.Before(window.Start) && t.Before(window.End) {
+			return false
+		}
+	}
+	if len(this.ActiveHours) == 0 {
+		return true
+	}
+	location := this.location
+	if location == nil {
+		location = time.Local
+	}
+	t = t.In(location)
+	for _, hours := range this.ActiveHours {
+		if hours.contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// DefersInactiveMessages returns true if messages outside the active hours are held back instead of dropped
+func (this *Subscription) DefersInactiveMessages() bool {
+	return this.OutsideActiveHours == OutsideActiveHoursDefer
+}
+
+func (this ActiveHours) contains(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	if this.start < this.end {
+		return this.matchDay(t.Weekday()) && minute >= this.start && minute < this.end
+	}
+	//over midnight: the part after midnight belongs to the range of the previous day
+	previous := (t.Weekday() + 6) % 7
+	return (this.matchDay(t.Weekday()) && minute >= this.start) || (this.matchDay(previous) && minute < this.end)
+}
+
+func (this ActiveHours) matchDay(day time.Weekday) bool {
+	return this.days&(1<<uint(day)) != 0
+}
+
+func (this *Subscription) prepareSchedule() (err error) {
+	switch this.OutsideActiveHours {
+	case "", OutsideActiveHoursDrop, OutsideActiveHoursDefer:
+	default:
+		return fmt.Errorf("unknown outside_active_hours %#v in subscription %v", this.OutsideActiveHours, this.Key)
+	}
+	this.location = time.Local
+	if this.TimeZone != "" {
+		this.location, err = time.LoadLocation(this.TimeZone)
+		if err != nil {
+			return fmt.Errorf("invalid time_zone in subscription %v: %w", this.Key, err)
+		}
+	}
+	for _, window := range this.MaintenanceWindows {
+		if !window.End.After(window.Start) {
+			return fmt.Errorf("end of maintenance window is not after its start in subscription %v", this.Key)
+		}
+	}
+	list, err := prepareCopy(this.ActiveHours, (*ActiveHours).prepare)
+	if err != nil {
+		return fmt.Errorf("invalid active_hours in subscription %v: %w", this.Key, err)
+	}
+	this.ActiveHours = list
+	return nil
+}
+
+func (this *ActiveHours) prepare() (err error) {
+	this.days = 0
+	for _, day := range this.Days {
+		index := -1
+		for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+			if strings.EqualFold(day, weekday.String()[:3]) || strings.EqualFold(day, weekday.String()) {
+				index = int(weekday)
+			}
+		}
+		if index < 0 {
+			return fmt.Errorf("unknown day %#v", day)
+		}
+		this.days |= 1 << uint(index)
+	}
+	if len(this.Days) == 0 {
+		this.days = 0b1111111
+	}
+	this.start, err = parseTimeOfDay(this.Start)
+	if err != nil {
+		return err
+	}
+	this.end, err = parseTimeOfDay(this.End)
+	if err != nil {
+		return err
+	}
+	if this.start == this.end || this.start == 24*60 {
+		return errors.New("empty time range " + this.Start + "-" + this.End)
+	}
+	if this.end == 0 {
+		//"00:00" ends the range with the day, like "24:00"
+		this.end = 24 * 60
+	}
+	return nil
+}
+
+// parseTimeOfDay returns the minute of the day of values like "08:30" or "24:00"
+func parseTimeOfDay(value string) (int, error) {
+	hourPart, minutePart, ok := strings.Cut(value, ":")
+	hour, hourErr := strconv.Atoi(hourPart)
+	minute, minuteErr := strconv.Atoi(minutePart)
+	if !ok || hourErr != nil || minuteErr != nil || hour < 0 || minute < 0 || minute > 59 || hour > 24 || (hour == 24 && minute > 0) {
+		return 0, fmt.Errorf("invalid time of day %#v, expected hh:mm", value)
+	}
+	return hour*60 + minute, nil
+}
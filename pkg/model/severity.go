@@ -0,0 +1,88 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type Severity string
+
+const SeverityDebug Severity = "debug"
+const SeverityInfo Severity = "info"
+const SeverityNotice Severity = "notice"
+const SeverityWarning Severity = "warning"
+const SeverityError Severity = "error"
+const SeverityCritical Severity = "critical"
+
+// DefaultSeverity is used for messages without severity and without a tag mapped to a severity
+const DefaultSeverity = SeverityInfo
+
+// Severities in ascending order
+var Severities = []Severity{SeverityDebug, SeverityInfo, SeverityNotice, SeverityWarning, SeverityError, SeverityCritical}
+
+// legacySeverityTags maps tags of senders, which do not set Message.Severity
+var legacySeverityTags = map[string]Severity{
+	KnownTags.Error:        SeverityError,
+	KnownTags.Warning:      SeverityWarning,
+	KnownTags.Notification: SeverityNotice,
+}
+
+func ParseSeverity(value string) (Severity, error) {
+	result := Severity(strings.ToLower(strings.TrimSpace(value)))
+	if !slices.Contains(Severities, result) {
+		return "", fmt.Errorf("%w: unknown severity %#v", ErrInvalid, value)
+	}
+	return result, nil
+}
+
+// Level returns the position of the severity in Severities or -1 if it is unknown
+func (this Severity) Level() int {
+	return slices.Index(Severities, this)
+}
+
+func (this *Severity) UnmarshalText(text []byte) (err error) {
+	if len(text) == 0 {
+		*this = ""
+		return nil
+	}
+	*this, err = ParseSeverity(string(text))
+	return err
+}
+
+// GetSeverity returns Message.Severity or, if not set, the highest severity of the tags of the message
+func (this Message) GetSeverity() Severity {
+	if this.Severity != "" {
+		return this.Severity
+	}
+	result := Severity("")
+	for _, tag := range this.Tags {
+		severity, ok := legacySeverityTags[strings.ToLower(tag)]
+		if !ok {
+			severity, _ = ParseSeverity(tag)
+		}
+		if severity.Level() > result.Level() {
+			result = severity
+		}
+	}
+	if result == "" {
+		return DefaultSeverity
+	}
+	return result
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Silence mutes matching messages between Start and End, e.g. while a known incident is in progress
+type Silence struct {
+	Id            string          `json:"id"`
+	Matchers      []MessageFilter `json:"matchers"`                //a message is silenced if every matcher matches
+	Subscriptions []string        `json:"subscriptions,omitempty"` //keys of the silenced subscriptions, defaults to all subscriptions
+	Start         time.Time       `json:"start"`                   //defaults to the creation time
+	End           time.Time       `json:"end"`
+	Author        string          `json:"author"`
+	Comment       string          `json:"comment"`
+	Created       time.Time       `json:"created"`
+}
+
+// SilencedMessage records a message, which has not been sent to the listed subscriptions because of the listed silences
+type SilencedMessage struct {
+	Id            string    `json:"id"`
+	Time          time.Time `json:"time"`
+	Message       Message   `json:"message"`
+	Subscriptions []string  `json:"subscriptions"`
+	Silences      []string  `json:"silences"`
+}
+
+// Prepare validates the silence and compiles the patterns of its matchers
+func (this *Silence) Prepare() (err error) {
+	if len(this.Matchers) == 0 {
+		return fmt.Errorf("%w: silence without matchers", ErrInvalid)
+	}
+	this.Matchers, err = prepareFilters(this.Matchers)
+	if err != nil {
+		return fmt.Errorf("%w: invalid matcher in silence %v: %w", ErrInvalid, this.Id, err)
+	}
+	if this.End.IsZero() {
+		return fmt.Errorf("%w: silence %v without end", ErrInvalid, this.Id)
+	}
+	if !this.End.After(this.Start) {
+		return fmt.Errorf("%w: end of silence %v is not after its start", ErrInvalid, this.Id)
+	}
+	if this.Author == "" {
+		return fmt.Errorf("%w: silence %v without author", ErrInvalid, this.Id)
+	}
+	return nil
+}
+
+// IsActive returns true if t is between Start (inclusive) and End (exclusive)
+func (this *Silence) IsActive(t time.Time) bool {
+	return !t.Before(this.Start) && t.Before(this.End)
+}
+
+// IsExpired returns true if the silence ended before t
+func (this *Silence) IsExpired(t time.Time) bool {
+	return !t.Before(this.End)
+}
+
+// Match returns true if the silence mutes the message for the subscription
+func (this *Silence) Match(message Message, subscriptionKey string) bool {
+	if len(this.Subscriptions) > 0 && !slices.Contains(this.Subscriptions, subscriptionKey) {
+		return false
+	}
+	for _, matcher := range this.Matchers {
+		if !matcher.Match(message) {
+			return false
+		}
+	}
+	return true
+}
@@ -17,15 +17,30 @@
 package slack
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
-	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
-	"github.com/SENERGY-Platform/developer-notifications/pkg/receiver/registry"
+	htmltemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
 	"net/smtp"
+	"net/textproto"
+	"strconv"
 	"strings"
 	"sync"
 	"text/template"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/receiver/registry"
 )
 
 func init() {
@@ -40,7 +55,7 @@ func New(ctx context.Context, wg *sync.WaitGroup, config configuration.Config) (
 	if config.MailSmtpHost == "" || config.MailSmtpHost == "-" {
 		return nil, fmt.Errorf("%w (missing mail smtp host)", registry.ErrNotConfigured)
 	}
-	if config.MailPassword == "" || config.MailPassword == "-" {
+	if (config.MailPassword == "" || config.MailPassword == "-") && config.MailAuth != AuthNone {
 		return nil, fmt.Errorf("%w (missing mail smtp password)", registry.ErrNotConfigured)
 	}
 	if config.MailFrom == "" || config.MailFrom == "-" {
@@ -49,33 +64,213 @@ func New(ctx context.Context, wg *sync.WaitGroup, config configuration.Config) (
 	if config.MailSmtpPort == "" || config.MailSmtpPort == "-" {
 		return nil, fmt.Errorf("%w (missing mail smtp port)", registry.ErrNotConfigured)
 	}
-	auth := smtp.PlainAuth("", config.MailFrom, config.MailPassword, config.MailSmtpHost)
+	from, err := mail.ParseAddress(config.MailFrom)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mail_from: %w", err)
+	}
+	auth, err := getAuth(config, from.Address)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := getTlsConfig(config)
+	if err != nil {
+		return nil, err
+	}
 
 	tmpl, err := template.New("mailmsg").Parse(Template)
 	if err != nil {
 		return nil, err
 	}
-	return &Receiver{config: config, tmpl: tmpl, auth: auth}, nil
+	htmlTmpl, err := htmltemplate.New("mailhtml").Parse(HtmlTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return &Receiver{config: config, tmpl: tmpl, htmlTmpl: htmlTmpl, auth: auth, tlsConfig: tlsConfig, from: from, sendTimeout: defaultSendTimeout}, nil
 }
 
 type Receiver struct {
-	config configuration.Config
-	tmpl   *template.Template
-	auth   smtp.Auth
+	config      configuration.Config
+	tmpl        *template.Template
+	htmlTmpl    *htmltemplate.Template
+	auth        smtp.Auth //nil if config.MailAuth is "none"
+	tlsConfig   *tls.Config
+	from        *mail.Address //parsed config.MailFrom
+	sendTimeout time.Duration
+}
+
+// Info is the AdditionalReceiverInfo of mail subscriptions; a plain string is interpreted as comma separated list of To addresses
+type Info struct {
+	To      []string `json:"to"`
+	Cc      []string `json:"cc"`
+	Bcc     []string `json:"bcc"`
+	ReplyTo string   `json:"reply_to"`
+}
+
+type Recipients struct {
+	To      []*mail.Address
+	Cc      []*mail.Address
+	Bcc     []*mail.Address
+	ReplyTo *mail.Address
+}
+
+// ParseRecipients parses the AdditionalReceiverInfo and validates all addresses
+func ParseRecipients(additionalInfo string) (result Recipients, err error) {
+	info := Info{}
+	if strings.HasPrefix(strings.TrimSpace(additionalInfo), "{") {
+		err = json.Unmarshal([]byte(additionalInfo), &info)
+		if err != nil {
+			return result, err
+		}
+	} else if strings.TrimSpace(additionalInfo) != "" {
+		info.To = []string{additionalInfo}
+	}
+	parse := func(list []string) (addresses []*mail.Address, err error) {
+		for _, element := range list {
+			parsed, err := mail.ParseAddressList(element)
+			if err != nil {
+				return nil, fmt.Errorf("invalid address %#v: %w", element, err)
+			}
+			addresses = append(addresses, parsed...)
+		}
+		return addresses, nil
+	}
+	if result.To, err = parse(info.To); err != nil {
+		return result, err
+	}
+	if result.Cc, err = parse(info.Cc); err != nil {
+		return result, err
+	}
+	if result.Bcc, err = parse(info.Bcc); err != nil {
+		return result, err
+	}
+	if info.ReplyTo != "" {
+		result.ReplyTo, err = mail.ParseAddress(info.ReplyTo)
+		if err != nil {
+			return result, fmt.Errorf("invalid reply_to address %#v: %w", info.ReplyTo, err)
+		}
+	}
+	if len(result.To)+len(result.Cc)+len(result.Bcc) == 0 {
+		return result, errors.New("missing recipient")
+	}
+	return result, nil
+}
+
+// Envelope returns the addresses of all To, Cc and Bcc recipients
+func (this Recipients) Envelope() (result []string) {
+	for _, list := range [][]*mail.Address{this.To, this.Cc, this.Bcc} {
+		for _, address := range list {
+			result = append(result, address.Address)
+		}
+	}
+	return result
 }
 
 func (this *Receiver) Send(message model.Message, additionalInfo string) error {
-	pl, err := this.CreatePayload(message)
+	recipients, err := ParseRecipients(additionalInfo)
 	if err != nil {
 		return err
 	}
-	return this.send(additionalInfo, message.Title, pl)
+	msg, err := this.CreateMail(recipients, message)
+	if err != nil {
+		return err
+	}
+	return this.send(recipients.Envelope(), msg)
+}
+
+func (this *Receiver) ValidateAdditionalInfo(additionalInfo string) error {
+	_, err := ParseRecipients(additionalInfo)
+	return err
 }
 
-func (this *Receiver) send(to string, subject, body string) error {
-	toList := []string{to}
-	msg := []byte(fmt.Sprintf("To: %v\r\nSubject: %v\r\n\r\n%v", to, subject, body))
-	return smtp.SendMail(this.config.MailSmtpHost+":"+this.config.MailSmtpPort, this.auth, this.config.MailFrom, toList, msg)
+// CreateMail creates a multipart/alternative mail with the text template as first and the html template as second part
+// Bcc recipients are only part of the smtp envelope, not of the mail headers.
+func (this *Receiver) CreateMail(recipients Recipients, message model.Message) (result []byte, err error) {
+	text, err := this.CreatePayload(message)
+	if err != nil {
+		return nil, err
+	}
+	html, err := this.CreateHtmlPayload(message)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.Buffer{}
+	writer := multipart.NewWriter(&buf)
+	now := time.Now()
+	headers := []string{"From: " + this.from.String()}
+	if len(recipients.To) > 0 {
+		headers = append(headers, "To: "+formatAddressList(recipients.To))
+	} else {
+		headers = append(headers, "To: undisclosed-recipients:;")
+	}
+	if len(recipients.Cc) > 0 {
+		headers = append(headers, "Cc: "+formatAddressList(recipients.Cc))
+	}
+	if recipients.ReplyTo != nil {
+		headers = append(headers, "Reply-To: "+recipients.ReplyTo.String())
+	}
+	headers = append(headers,
+		"Subject: "+mime.QEncoding.Encode("utf-8", message.Title),
+		"Date: "+now.Format(time.RFC1123Z),
+		"Message-ID: "+this.createMessageId(now),
+		"MIME-Version: 1.0",
+		"Content-Type: multipart/alternative; boundary=\""+writer.Boundary()+"\"",
+	)
+	buf.WriteString(strings.Join(headers, "\r\n") + "\r\n\r\n")
+
+	for _, part := range []struct {
+		contentType string
+		content     string
+	}{{contentType: "text/plain; charset=utf-8", content: text}, {contentType: "text/html; charset=utf-8", content: html}} {
+		w, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		_, err = qp.Write([]byte(part.content))
+		if err != nil {
+			return nil, err
+		}
+		err = qp.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func formatAddressList(list []*mail.Address) string {
+	result := []string{}
+	for _, address := range list {
+		result = append(result, address.String())
+	}
+	return strings.Join(result, ", ")
+}
+
+func (this *Receiver) createMessageId(now time.Time) string {
+	domain := "localhost"
+	if i := strings.LastIndex(this.from.Address, "@"); i >= 0 {
+		domain = this.from.Address[i+1:]
+	}
+	random := make([]byte, 8)
+	_, _ = rand.Read(random)
+	return "<" + strconv.FormatInt(now.UnixNano(), 36) + "." + hex.EncodeToString(random) + "@" + domain + ">"
+}
+
+func (this *Receiver) CreateHtmlPayload(message model.Message) (result string, err error) {
+	str := strings.Builder{}
+	err = this.htmlTmpl.Execute(&str, message)
+	if err != nil {
+		return "", err
+	}
+	return str.String(), nil
 }
 
 func (this *Receiver) CreatePayload(message model.Message) (result string, err error) {
@@ -17,10 +17,20 @@
 package slack
 
 import (
+	"bytes"
 	"context"
-	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"reflect"
+	"strings"
 	"sync"
 	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
 )
 
 func TestReceiver_send(t *testing.T) {
@@ -36,9 +46,137 @@ func TestReceiver_send(t *testing.T) {
 		return
 	}
 
-	err = receiver.send("", "Test", "my test body")
+	err = receiver.Send(model.Message{Title: "Test", Body: "my test body"}, "")
 	if err != nil {
 		t.Error(err)
 		return
 	}
 }
+
+func TestReceiver_CreateMail(t *testing.T) {
+	receiver, err := New(context.Background(), &sync.WaitGroup{}, configuration.Config{
+		MailSmtpHost: "localhost",
+		MailSmtpPort: "25",
+		MailFrom:     "Benachrichtigungen für Entwickler <notifications@example.com>",
+		MailPassword: "placeholder",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recipients, err := ParseRecipients(`{"to": ["dev@example.com"], "cc": ["Ops <ops@example.com>, qa@example.com"], "bcc": ["secret@example.com"], "reply_to": "support@example.com"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if envelope := recipients.Envelope(); !reflect.DeepEqual(envelope, []string{"dev@example.com", "ops@example.com", "qa@example.com", "secret@example.com"}) {
+		t.Error(envelope)
+	}
+	msg, err := receiver.CreateMail(recipients, model.Message{
+		Sender:     "github.com/SENERGY-Platform/developer-notifications",
+		Title:      "Fehler: Verbindung überlastet",
+		Body:       "<b>not bold</b>",
+		Tags:       []string{"error"},
+		Labels:     map[string]string{"environment": "prod"},
+		Repetition: &model.Repetition{Count: 3, Since: time.Now()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(msg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject != "Fehler: Verbindung überlastet" {
+		t.Error(subject)
+	}
+	if !strings.HasPrefix(parsed.Header.Get("Subject"), "=?utf-8?") {
+		t.Error("subject is not encoded", parsed.Header.Get("Subject"))
+	}
+	if !strings.HasPrefix(parsed.Header.Get("From"), "=?utf-8?") || parsed.Header.Get("To") != "<dev@example.com>" {
+		t.Errorf("%#v", parsed.Header)
+	}
+	if from, err := parsed.Header.AddressList("From"); err != nil || len(from) != 1 || from[0].Name != "Benachrichtigungen für Entwickler" {
+		t.Error(err, from)
+	}
+	if parsed.Header.Get("Cc") != `"Ops" <ops@example.com>, <qa@example.com>` || parsed.Header.Get("Reply-To") != "<support@example.com>" {
+		t.Errorf("%#v", parsed.Header)
+	}
+	if parsed.Header.Get("Bcc") != "" || bytes.Contains(msg, []byte("secret@example.com")) {
+		t.Error("bcc recipient must not be part of the mail")
+	}
+	if _, err = parsed.Header.Date(); err != nil {
+		t.Error(err)
+	}
+	if !strings.HasSuffix(parsed.Header.Get("Message-ID"), "@example.com>") {
+		t.Error(parsed.Header.Get("Message-ID"))
+	}
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mediaType != "multipart/alternative" {
+		t.Fatal(mediaType)
+	}
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	parts := map[string]string{}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(part) //quoted-printable is decoded by the multipart reader
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts[part.Header.Get("Content-Type")] = string(content)
+	}
+	text := parts["text/plain; charset=utf-8"]
+	html := parts["text/html; charset=utf-8"]
+	if !strings.Contains(text, "Fehler: Verbindung überlastet") || !strings.Contains(text, "<b>not bold</b>") || !strings.Contains(text, "environment=prod") {
+		t.Error(text)
+	}
+	if !strings.Contains(html, "<h2>Fehler: Verbindung überlastet</h2>") || !strings.Contains(html, "&lt;b&gt;not bold&lt;/b&gt;") || !strings.Contains(html, "<code>environment=prod</code>") || !strings.Contains(html, "<em>repeated 3 times since ") {
+		t.Error(html)
+	}
+}
+
+func TestParseRecipients(t *testing.T) {
+	valid := map[string][]string{
+		"example@example.com":                        {"example@example.com"},
+		"a@example.com, B <b@example.com>":           {"a@example.com", "b@example.com"},
+		`{"to": ["a@example.com", "b@example.com"]}`: {"a@example.com", "b@example.com"},
+		`{"bcc": ["a@example.com"]}`:                 {"a@example.com"},
+	}
+	for info, expected := range valid {
+		recipients, err := ParseRecipients(info)
+		if err != nil {
+			t.Error(info, err)
+			continue
+		}
+		if !reflect.DeepEqual(recipients.Envelope(), expected) {
+			t.Error(info, recipients.Envelope())
+		}
+	}
+	invalid := []string{
+		"",
+		"<mail-address-to-be-send-to>",
+		"not an address",
+		`{"to": []}`,
+		`{"to": ["a@example.com"], "cc": ["invalid"]}`,
+		`{"to": ["a@example.com"], "reply_to": "invalid"}`,
+	}
+	for _, info := range invalid {
+		if _, err := ParseRecipients(info); err == nil {
+			t.Error("expected error for", info)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package slack
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"os"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+)
+
+const TlsStartTls = "starttls"
+const TlsStartTlsRequired = "starttls_required"
+const TlsImplicit = "tls"
+const TlsNone = "none"
+
+const AuthPlain = "plain"
+const AuthLogin = "login"
+const AuthCramMd5 = "cram-md5"
+const AuthNone = "none"
+
+const dialTimeout = 30 * time.Second
+
+// defaultSendTimeout limits the whole smtp exchange, so that a stalled server does not block the sender
+const defaultSendTimeout = 2 * time.Minute
+
+func getAuth(config configuration.Config, envelopeFrom string) (smtp.Auth, error) {
+	username := config.MailUsername
+	if username == "" || username == "-" {
+		username = envelopeFrom
+	}
+	//net/smtp and loginAuth refuse to send credentials over unencrypted connections, except to localhost
+	if config.MailTls == TlsNone && (config.MailAuth == "" || config.MailAuth == AuthPlain || config.MailAuth == AuthLogin) && !isLocalhost(config.MailSmtpHost) {
+		return nil, errors.New("mail_auth " + config.MailAuth + " requires tls for " + config.MailSmtpHost + ", use mail_tls starttls or tls")
+	}
+	switch config.MailAuth {
+	case "", AuthPlain:
+		return smtp.PlainAuth("", username, config.MailPassword, config.MailSmtpHost), nil
+	case AuthLogin:
+		return &loginAuth{username: username, password: config.MailPassword, host: config.MailSmtpHost}, nil
+	case AuthCramMd5:
+		return smtp.CRAMMD5Auth(username, config.MailPassword), nil
+	case AuthNone:
+		return nil, nil
+	default:
+		return nil, errors.New("unknown mail_auth " + config.MailAuth)
+	}
+}
+
+func getTlsConfig(config configuration.Config) (*tls.Config, error) {
+	switch config.MailTls {
+	case "", TlsStartTls, TlsStartTlsRequired, TlsImplicit, TlsNone:
+	default:
+		return nil, errors.New("unknown mail_tls " + config.MailTls)
+	}
+	result := &tls.Config{ServerName: config.MailSmtpHost}
+	if config.MailTlsServerName != "" && config.MailTlsServerName != "-" {
+		result.ServerName = config.MailTlsServerName
+	}
+	if config.MailTlsCaFile != "" && config.MailTlsCaFile != "-" {
+		pem, err := os.ReadFile(config.MailTlsCaFile)
+		if err != nil {
+			return nil, err
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificates found in mail_tls_ca_file")
+		}
+		result.RootCAs = pool
+	}
+	return result, nil
+}
+
+// send replaces smtp.SendMail to support implicit tls, enforced starttls and relays without authentication
+func (this *Receiver) send(toList []string, msg []byte) error {
+	addr := net.JoinHostPort(this.config.MailSmtpHost, this.config.MailSmtpPort)
+	var conn net.Conn
+	var err error
+	if this.config.MailTls == TlsImplicit {
+		conn, err = tls.DialWithDialer(&net.Dialer{Timeout: dialTimeout}, "tcp", addr, this.tlsConfig)
+	} else {
+		conn, err = net.DialTimeout("tcp", addr, dialTimeout)
+	}
+	if err != nil {
+		return err
+	}
+	err = conn.SetDeadline(time.Now().Add(this.sendTimeout))
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	client, err := smtp.NewClient(conn, this.config.MailSmtpHost)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	switch this.config.MailTls {
+	case "", TlsStartTls, TlsStartTlsRequired:
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			err = client.StartTLS(this.tlsConfig)
+			if err != nil {
+				return err
+			}
+		} else if this.config.MailTls == TlsStartTlsRequired {
+			return errors.New("smtp server does not support STARTTLS")
+		}
+	}
+
+	if this.auth != nil {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return errors.New("smtp server does not support AUTH")
+		}
+		err = client.Auth(this.auth)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = client.Mail(this.from.Address)
+	if err != nil {
+		return err
+	}
+	for _, to := range toList {
+		err = client.Rcpt(to)
+		if err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(msg)
+	if err != nil {
+		return err
+	}
+	err = w.Close()
+	if err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// loginAuth implements the LOGIN mechanism, which is not part of net/smtp.
+// Like smtp.PlainAuth it refuses to send credentials over unencrypted connections, except to localhost.
+type loginAuth struct {
+	username string
+	password string
+	host     string
+}
+
+func (this *loginAuth) Start(server *smtp.ServerInfo) (proto string, toServer []byte, err error) {
+	if !server.TLS && !isLocalhost(server.Name) {
+		return "", nil, errors.New("unencrypted connection")
+	}
+	if server.Name != this.host {
+		return "", nil, errors.New("wrong host name")
+	}
+	return "LOGIN", nil, nil
+}
+
+func (this *loginAuth) Next(fromServer []byte, more bool) (toServer []byte, err error) {
+	if !more {
+		return nil, nil
+	}
+	switch string(fromServer) {
+	case "Username:", "User Name\x00":
+		return []byte(this.username), nil
+	case "Password:", "Password\x00":
+		return []byte(this.password), nil
+	default:
+		return nil, fmt.Errorf("unexpected server challenge %#v", string(fromServer))
+	}
+}
+
+func isLocalhost(name string) bool {
+	return name == "localhost" || name == "127.0.0.1" || name == "::1"
+}
@@ -0,0 +1,266 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package slack
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net"
+	"net/textproto"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+type smtpSession struct {
+	Auth []string
+	From string
+	To   []string
+	Data string
+}
+
+// startSmtpStub starts a minimal smtp server without STARTTLS, which records the sessions
+func startSmtpStub(t *testing.T) (port string, sessions func() []smtpSession) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+	mux := sync.Mutex{}
+	result := []smtpSession{}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			session := smtpSession{}
+			text := textproto.NewConn(conn)
+			reply := func(lines ...string) {
+				for _, line := range lines {
+					_ = text.PrintfLine("%s", line)
+				}
+			}
+			reply("220 stub ESMTP")
+		loop:
+			for {
+				line, err := text.ReadLine()
+				if err != nil {
+					break
+				}
+				command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+				switch {
+				case command == "EHLO":
+					reply("250-stub", "250 AUTH PLAIN LOGIN")
+				case strings.HasPrefix(strings.ToUpper(line), "AUTH PLAIN"):
+					session.Auth = append(session.Auth, line)
+					reply("235 ok")
+				case strings.HasPrefix(strings.ToUpper(line), "AUTH LOGIN"):
+					reply("334 " + base64.StdEncoding.EncodeToString([]byte("Username:")))
+					user, _ := text.ReadLine()
+					reply("334 " + base64.StdEncoding.EncodeToString([]byte("Password:")))
+					password, _ := text.ReadLine()
+					u, _ := base64.StdEncoding.DecodeString(user)
+					p, _ := base64.StdEncoding.DecodeString(password)
+					session.Auth = append(session.Auth, "LOGIN "+string(u)+" "+string(p))
+					reply("235 ok")
+				case command == "MAIL":
+					session.From = line
+					reply("250 ok")
+				case command == "RCPT":
+					session.To = append(session.To, line)
+					reply("250 ok")
+				case command == "DATA":
+					reply("354 go ahead")
+					lines, _ := text.ReadDotLines()
+					session.Data = strings.Join(lines, "\n")
+					reply("250 ok")
+				case command == "QUIT":
+					reply("221 bye")
+					break loop
+				default:
+					reply("502 unknown")
+				}
+			}
+			_ = conn.Close()
+			mux.Lock()
+			result = append(result, session)
+			mux.Unlock()
+		}
+	}()
+	return strconv.Itoa(listener.Addr().(*net.TCPAddr).Port), func() []smtpSession {
+		mux.Lock()
+		defer mux.Unlock()
+		return append([]smtpSession{}, result...)
+	}
+}
+
+func TestReceiver_SendSmtp(t *testing.T) {
+	port, sessions := startSmtpStub(t)
+	message := model.Message{Title: "Test", Body: "my test body"}
+
+	t.Run("relay without auth", func(t *testing.T) {
+		receiver, err := New(context.Background(), &sync.WaitGroup{}, configuration.Config{
+			MailSmtpHost: "127.0.0.1",
+			MailSmtpPort: port,
+			MailFrom:     "Notifications <notifications@example.com>",
+			MailAuth:     AuthNone,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = receiver.Send(message, `{"to": ["a@example.com"], "bcc": ["b@example.com"]}`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		session := sessions()[len(sessions())-1]
+		if len(session.Auth) != 0 || session.From != "MAIL FROM:<notifications@example.com> BODY=8BITMIME" && session.From != "MAIL FROM:<notifications@example.com>" {
+			t.Errorf("%#v", session)
+		}
+		if !reflect.DeepEqual(session.To, []string{"RCPT TO:<a@example.com>", "RCPT TO:<b@example.com>"}) {
+			t.Errorf("%#v", session.To)
+		}
+		if !strings.Contains(session.Data, "Subject: Test") {
+			t.Error(session.Data)
+		}
+	})
+
+	t.Run("login auth", func(t *testing.T) {
+		receiver, err := New(context.Background(), &sync.WaitGroup{}, configuration.Config{
+			MailSmtpHost: "127.0.0.1",
+			MailSmtpPort: port,
+			MailFrom:     "notifications@example.com",
+			MailUsername: "user",
+			MailPassword: "secret",
+			MailAuth:     AuthLogin,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = receiver.Send(message, "a@example.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+		session := sessions()[len(sessions())-1]
+		if !reflect.DeepEqual(session.Auth, []string{"LOGIN user secret"}) {
+			t.Errorf("%#v", session.Auth)
+		}
+	})
+
+	t.Run("plain auth", func(t *testing.T) {
+		receiver, err := New(context.Background(), &sync.WaitGroup{}, configuration.Config{
+			MailSmtpHost: "127.0.0.1",
+			MailSmtpPort: port,
+			MailFrom:     "notifications@example.com",
+			MailPassword: "secret",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = receiver.Send(message, "a@example.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+		session := sessions()[len(sessions())-1]
+		expected := "AUTH PLAIN " + base64.StdEncoding.EncodeToString([]byte("\x00notifications@example.com\x00secret"))
+		if !reflect.DeepEqual(session.Auth, []string{expected}) {
+			t.Errorf("%#v", session.Auth)
+		}
+	})
+
+	t.Run("required starttls", func(t *testing.T) {
+		receiver, err := New(context.Background(), &sync.WaitGroup{}, configuration.Config{
+			MailSmtpHost: "127.0.0.1",
+			MailSmtpPort: port,
+			MailFrom:     "notifications@example.com",
+			MailAuth:     AuthNone,
+			MailTls:      TlsStartTlsRequired,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = receiver.Send(message, "a@example.com")
+		if err == nil || !strings.Contains(err.Error(), "STARTTLS") {
+			t.Error(err)
+		}
+	})
+
+	t.Run("missing password", func(t *testing.T) {
+		_, err := New(context.Background(), &sync.WaitGroup{}, configuration.Config{
+			MailSmtpHost: "127.0.0.1",
+			MailSmtpPort: port,
+			MailFrom:     "notifications@example.com",
+		})
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("plain auth without tls", func(t *testing.T) {
+		_, err := New(context.Background(), &sync.WaitGroup{}, configuration.Config{
+			MailSmtpHost: "mail.example.com",
+			MailSmtpPort: "25",
+			MailFrom:     "notifications@example.com",
+			MailPassword: "secret",
+			MailTls:      TlsNone,
+		})
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+func TestReceiver_SendSmtpTimeout(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = io.Copy(io.Discard, conn) //accept, but never respond
+	}()
+	receiver, err := New(context.Background(), &sync.WaitGroup{}, configuration.Config{
+		MailSmtpHost: "127.0.0.1",
+		MailSmtpPort: strconv.Itoa(listener.Addr().(*net.TCPAddr).Port),
+		MailFrom:     "notifications@example.com",
+		MailAuth:     AuthNone,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	receiver.sendTimeout = 100 * time.Millisecond
+	start := time.Now()
+	err = receiver.Send(model.Message{Title: "Test"}, "a@example.com")
+	if err == nil {
+		t.Error("expected error")
+	}
+	if time.Since(start) > time.Second {
+		t.Error(time.Since(start))
+	}
+}
@@ -20,3 +20,6 @@ import _ "embed"
 
 //go:embed message.tmpl
 var Template string
+
+//go:embed message.html.tmpl
+var HtmlTemplate string
@@ -19,12 +19,15 @@ package receiver
 import (
 	"context"
 	"errors"
+	"slices"
 	"sync"
 
 	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
 	_ "github.com/SENERGY-Platform/developer-notifications/pkg/receiver/mail"
 	"github.com/SENERGY-Platform/developer-notifications/pkg/receiver/registry"
 	_ "github.com/SENERGY-Platform/developer-notifications/pkg/receiver/slack"
+	_ "github.com/SENERGY-Platform/developer-notifications/pkg/receiver/teams"
+	_ "github.com/SENERGY-Platform/developer-notifications/pkg/receiver/webhook"
 )
 
 func New(ctx context.Context, wg *sync.WaitGroup, config configuration.Config) (result *Receivers, err error) {
@@ -53,3 +56,11 @@ func (this *Receivers) Get(name string) (receiver registry.Receiver, found bool)
 	receiver, found = this.reg[name]
 	return receiver, found
 }
+
+func (this *Receivers) Names() (result []string) {
+	for name := range this.reg {
+		result = append(result, name)
+	}
+	slices.Sort(result)
+	return result
+}
@@ -28,6 +28,17 @@ type Receiver interface {
 	Send(message model.Message, additionalInfo string) error
 }
 
+// InfoValidator may be implemented by receivers to check the AdditionalReceiverInfo of subscriptions when they are loaded
+type InfoValidator interface {
+	ValidateAdditionalInfo(additionalInfo string) error
+}
+
+// ThreadedReceiver may be implemented by receivers that group repeated notifications, e.g. as thread.
+// The threadKey is equal for messages that are equal in the sense of the subscriptions distinct_time_window.
+type ThreadedReceiver interface {
+	SendThreaded(message model.Message, additionalInfo string, threadKey string) error
+}
+
 var ReceiverFactories []func(ctx context.Context, wg *sync.WaitGroup, config configuration.Config) (name string, receiver Receiver, err error)
 
 var ErrNotConfigured = errors.New("not configured")
@@ -0,0 +1,233 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+// newApiStub simulates chat.postMessage and records the received payloads
+func newApiStub(t *testing.T, token string) (server *httptest.Server, received *[]Payload) {
+	received = &[]Payload{}
+	mux := sync.Mutex{}
+	server = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		mux.Lock()
+		defer mux.Unlock()
+		if request.URL.Path != "/chat.postMessage" {
+			http.Error(writer, "unknown method", http.StatusNotFound)
+			return
+		}
+		if request.Header.Get("Authorization") != "Bearer "+token {
+			_ = json.NewEncoder(writer).Encode(ApiResponse{Ok: false, Error: "invalid_auth"})
+			return
+		}
+		pl := Payload{}
+		err := json.NewDecoder(request.Body).Decode(&pl)
+		if err != nil {
+			t.Error(err)
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		*received = append(*received, pl)
+		_ = json.NewEncoder(writer).Encode(ApiResponse{Ok: true, Channel: pl.Channel, Ts: "1700000000." + strconv.Itoa(len(*received))})
+	}))
+	return server, received
+}
+
+func TestReceiver_PostMessage(t *testing.T) {
+	server, received := newApiStub(t, "xoxb-test")
+	defer server.Close()
+
+	receiver, err := New(context.Background(), &sync.WaitGroup{}, configuration.Config{
+		SlackBotToken: "xoxb-test",
+		SlackChannel:  "#default",
+		SlackApiUrl:   server.URL,
+		SlackBlockKit: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := model.Message{
+		Sender: "github.com/SENERGY-Platform/developer-notifications",
+		Title:  "Test Message",
+		Body:   "a < b",
+		Tags:   []string{model.KnownTags.Error},
+	}
+
+	for _, info := range []string{"", `{"channel": "#team-a"}`} {
+		err = receiver.Send(message, info)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(*received) != 2 || (*received)[0].Channel != "#default" || (*received)[1].Channel != "#team-a" {
+		t.Fatalf("%#v", *received)
+	}
+	pl := (*received)[0]
+	//the blocks are not repeated by the fallback text
+	if pl.Text != "Test Message" {
+		t.Error(pl.Text)
+	}
+	expected := []Block{
+		{Type: "header", Text: &Text{Type: "plain_text", Text: "Test Message"}},
+		{Type: "context", Elements: []Text{{Type: "mrkdwn", Text: "From: _github.com/SENERGY-Platform/developer-notifications_"}, {Type: "mrkdwn", Text: "Tags: `error`"}}},
+		{Type: "section", Text: &Text{Type: "mrkdwn", Text: "a &lt; b"}},
+	}
+	if !reflect.DeepEqual(pl.Blocks, expected) {
+		t.Errorf("\n%#v\n%#v\n", expected, pl.Blocks)
+	}
+
+	t.Run("api error", func(t *testing.T) {
+		invalid, err := New(context.Background(), &sync.WaitGroup{}, configuration.Config{SlackBotToken: "wrong", SlackChannel: "#default", SlackApiUrl: server.URL})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if invalid.Send(message, "") == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("missing channel", func(t *testing.T) {
+		noChannel, err := New(context.Background(), &sync.WaitGroup{}, configuration.Config{SlackBotToken: "xoxb-test", SlackApiUrl: server.URL})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if noChannel.ValidateAdditionalInfo("") == nil {
+			t.Error("expected error")
+		}
+		if noChannel.ValidateAdditionalInfo(`{"channel": "#team-a"}`) != nil {
+			t.Error("unexpected error")
+		}
+	})
+}
+
+func TestCreateBlocks(t *testing.T) {
+	body := make([]rune, maxSectionLength+10)
+	for i := range body {
+		body[i] = 'a'
+	}
+	blocks := CreateBlocks(model.Message{Title: string(body), Body: string(body)})
+	if len(blocks) != 4 {
+		t.Fatalf("%#v", len(blocks))
+	}
+	if len([]rune(blocks[0].Text.Text)) != maxHeaderLength {
+		t.Error(len([]rune(blocks[0].Text.Text)))
+	}
+	if len([]rune(blocks[2].Text.Text)) != maxSectionLength || len([]rune(blocks[3].Text.Text)) != 10 {
+		t.Error(len([]rune(blocks[2].Text.Text)), len([]rune(blocks[3].Text.Text)))
+	}
+
+	blocks = CreateBlocks(model.Message{Sender: "sender", Labels: map[string]string{"trace_id": "a<b", "environment": "prod"}})
+	if context := blocks[0].Elements; len(context) != 2 || context[1].Text != "Labels: `environment=prod` `trace_id=a&lt;b`" {
+		t.Errorf("%#v", context)
+	}
+}
+
+func TestReceiver_SendThreaded(t *testing.T) {
+	server, received := newApiStub(t, "xoxb-test")
+	defer server.Close()
+
+	config := configuration.Config{
+		SlackBotToken:        "xoxb-test",
+		SlackChannel:         "#default",
+		SlackApiUrl:          server.URL,
+		SlackThreads:         true,
+		SlackThreadBroadcast: true,
+		DataDir:              t.TempDir(),
+	}
+	receiver, err := New(context.Background(), &sync.WaitGroup{}, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := model.Message{Title: "Test Message"}
+	err = receiver.SendThreaded(message, "", "key-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = receiver.SendThreaded(message, "", "key-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = receiver.SendThreaded(message, "", "key-b")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//thread state must survive restarts
+	receiver, err = New(context.Background(), &sync.WaitGroup{}, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = receiver.SendThreaded(message, "", "key-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual := []string{}
+	for _, pl := range *received {
+		actual = append(actual, pl.ThreadTs+"/"+strconv.FormatBool(pl.ReplyBroadcast))
+	}
+	expected := []string{"/false", "1700000000.1/true", "/false", "1700000000.1/true"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("\n%#v\n%#v\n", expected, actual)
+	}
+
+	t.Run("expired threads", func(t *testing.T) {
+		keys, err := receiver.threads.Keys()
+		if err != nil || len(keys) != 2 {
+			t.Fatal(err, keys)
+		}
+		receiver.cleanupThreads(time.Now())
+		if keys, _ := receiver.threads.Keys(); len(keys) != 2 {
+			t.Error(keys)
+		}
+		receiver.cleanupThreads(time.Now().Add(DefaultThreadMaxAge))
+		if keys, _ := receiver.threads.Keys(); len(keys) != 0 {
+			t.Error(keys)
+		}
+
+		receiver.threadMaxAge = 0
+		count := len(*received)
+		err = receiver.SendThreaded(message, "", "key-a")
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = receiver.SendThreaded(message, "", "key-a")
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, pl := range (*received)[count:] {
+			if pl.ThreadTs != "" {
+				t.Error("expected new message instead of reply to expired thread")
+			}
+		}
+	})
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package slack
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+type Payload struct {
+	Channel        string  `json:"channel,omitempty"`
+	Text           string  `json:"text"`
+	Blocks         []Block `json:"blocks,omitempty"`
+	ThreadTs       string  `json:"thread_ts,omitempty"`
+	ReplyBroadcast bool    `json:"reply_broadcast,omitempty"`
+}
+
+type Block struct {
+	Type     string `json:"type"`
+	Text     *Text  `json:"text,omitempty"`
+	Elements []Text `json:"elements,omitempty"`
+}
+
+type Text struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type ApiResponse struct {
+	Ok      bool   `json:"ok"`
+	Error   string `json:"error"`
+	Channel string `json:"channel"`
+	Ts      string `json:"ts"`
+}
+
+// limits of https://api.slack.com/reference/block-kit/blocks
+const maxHeaderLength = 150
+const maxSectionLength = 3000
+
+// CreateBlocks creates a header with the title, a context with sender, tags, labels and repetitions and sections with the body
+func CreateBlocks(message model.Message) (result []Block) {
+	if message.Title != "" {
+		result = append(result, Block{Type: "header", Text: &Text{Type: "plain_text", Text: truncate(message.Title, maxHeaderLength)}})
+	}
+	context := []Text{{Type: "mrkdwn", Text: "From: _" + escape(message.Sender) + "_"}}
+	if message.Severity != "" {
+		context = append(context, Text{Type: "mrkdwn", Text: "Severity: *" + string(message.Severity) + "*"})
+	}
+	if len(message.Tags) > 0 {
+		tags := []string{}
+		for _, tag := range message.Tags {
+			tags = append(tags, "`"+escape(tag)+"`")
+		}
+		context = append(context, Text{Type: "mrkdwn", Text: "Tags: " + strings.Join(tags, " ")})
+	}
+	if len(message.Labels) > 0 {
+		labels := []string{}
+		for _, key := range slices.Sorted(maps.Keys(message.Labels)) {
+			labels = append(labels, "`"+escape(key+"="+message.Labels[key])+"`")
+		}
+		context = append(context, Text{Type: "mrkdwn", Text: "Labels: " + strings.Join(labels, " ")})
+	}
+	if message.Repetition != nil {
+		context = append(context, Text{Type: "mrkdwn", Text: "_" + message.Repetition.String() + "_"})
+	}
+	result = append(result, Block{Type: "context", Elements: context})
+	for _, part := range split(escape(message.Body), maxSectionLength) {
+		result = append(result, Block{Type: "section", Text: &Text{Type: "mrkdwn", Text: part}})
+	}
+	return result
+}
+
+// escape replaces the control characters of slack mrkdwn
+func escape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
+
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit-1]) + "…"
+}
+
+func split(text string, limit int) (result []string) {
+	runes := []rune(text)
+	for len(runes) > limit {
+		result = append(result, string(runes[:limit]))
+		runes = runes[limit:]
+	}
+	if len(runes) > 0 {
+		result = append(result, string(runes))
+	}
+	return result
+}
@@ -28,8 +28,10 @@ import (
 	"strings"
 	"sync"
 	"text/template"
+	"time"
 
 	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/kv"
 	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
 	"github.com/SENERGY-Platform/developer-notifications/pkg/receiver/registry"
 )
@@ -42,36 +44,168 @@ func init() {
 	})
 }
 
+const DefaultApiUrl = "https://slack.com/api"
+
+const DefaultThreadMaxAge = 24 * time.Hour
+
+// threadCleanupInterval is the interval in which threads older than the max age are removed
+const threadCleanupInterval = 10 * time.Minute
+
 func New(ctx context.Context, wg *sync.WaitGroup, config configuration.Config) (*Receiver, error) {
-	if config.SlackWebhookUrl == "" || config.SlackWebhookUrl == "-" {
-		return nil, fmt.Errorf("%w (missing slack webhook)", registry.ErrNotConfigured)
+	if (config.SlackWebhookUrl == "" || config.SlackWebhookUrl == "-") && len(config.SlackWebhooks) == 0 && !isSet(config.SlackBotToken) {
+		return nil, fmt.Errorf("%w (missing slack webhook or bot token)", registry.ErrNotConfigured)
+	}
+	if !isSet(config.SlackApiUrl) {
+		config.SlackApiUrl = DefaultApiUrl
 	}
 	tmpl, err := template.New("slackmsg").Parse(Template)
 	if err != nil {
 		return nil, err
 	}
-	return &Receiver{config: config, tmpl: tmpl}, nil
+	threads, err := kv.New(config.DataDir, "slack-threads")
+	if err != nil {
+		return nil, err
+	}
+	threadMaxAge := DefaultThreadMaxAge
+	if isSet(config.SlackThreadMaxAge) {
+		threadMaxAge, err = time.ParseDuration(config.SlackThreadMaxAge)
+		if err != nil {
+			return nil, fmt.Errorf("invalid slack_thread_max_age: %w", err)
+		}
+	}
+	result := &Receiver{config: config, client: &http.Client{Timeout: 30 * time.Second}, tmpl: tmpl, threads: threads, threadMaxAge: threadMaxAge}
+	if config.SlackThreads {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ticker := time.NewTicker(threadCleanupInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					result.cleanupThreads(time.Now())
+				}
+			}
+		}()
+	}
+	return result, nil
+}
+
+func isSet(value string) bool {
+	return value != "" && value != "-"
 }
 
 type Receiver struct {
-	config configuration.Config
-	tmpl   *template.Template
+	config       configuration.Config
+	client       *http.Client
+	tmpl         *template.Template
+	threads      kv.Store //channel and thread key -> Thread
+	threadMaxAge time.Duration
+}
+
+type Thread struct {
+	Channel string    `json:"channel"`
+	Ts      string    `json:"ts"`
+	Created time.Time `json:"created"`
+}
+
+// Info is the AdditionalReceiverInfo of slack subscriptions; a plain string is interpreted as Info.Webhook
+type Info struct {
+	Webhook string `json:"webhook"` //name of a webhook in config.SlackWebhooks; if empty, the bot token or config.SlackWebhookUrl is used
+	Channel string `json:"channel"` //overrides config.SlackChannel or the channel of the webhook, if the webhook allows it
+}
+
+func ParseInfo(additionalInfo string) (info Info, err error) {
+	if !strings.HasPrefix(strings.TrimSpace(additionalInfo), "{") {
+		return Info{Webhook: strings.TrimSpace(additionalInfo)}, nil
+	}
+	err = json.Unmarshal([]byte(additionalInfo), &info)
+	return info, err
 }
 
 func (this *Receiver) Send(message model.Message, additionalInfo string) error {
-	pl, err := this.CreatePayload(message)
+	return this.SendThreaded(message, additionalInfo, "")
+}
+
+// SendThreaded posts messages with the same threadKey as replies to the first message, if config.SlackThreads is set
+// and the bot token is used.
+func (this *Receiver) SendThreaded(message model.Message, additionalInfo string, threadKey string) error {
+	info, err := ParseInfo(additionalInfo)
+	if err != nil {
+		return err
+	}
+	pl, err := this.CreateMessage(message)
+	if err != nil {
+		return err
+	}
+	if this.useApi(info) {
+		pl.Channel, err = this.getChannel(info)
+		if err != nil {
+			return err
+		}
+		if this.config.SlackThreads && threadKey != "" {
+			return this.postThreaded(pl, threadKey)
+		}
+		_, err = this.postMessage(pl)
+		return err
+	}
+	webhook, err := this.getWebhookUrl(info)
+	if err != nil {
+		return err
+	}
+	pl.Channel = info.Channel
+	return this.send(webhook, pl)
+}
+
+func (this *Receiver) ValidateAdditionalInfo(additionalInfo string) error {
+	info, err := ParseInfo(additionalInfo)
 	if err != nil {
 		return err
 	}
-	return this.send(pl)
+	if this.useApi(info) {
+		_, err = this.getChannel(info)
+		return err
+	}
+	_, err = this.getWebhookUrl(info)
+	return err
+}
+
+func (this *Receiver) useApi(info Info) bool {
+	return info.Webhook == "" && isSet(this.config.SlackBotToken)
+}
+
+func (this *Receiver) getChannel(info Info) (string, error) {
+	if info.Channel != "" {
+		return info.Channel, nil
+	}
+	if !isSet(this.config.SlackChannel) {
+		return "", errors.New("missing slack channel")
+	}
+	return this.config.SlackChannel, nil
+}
+
+func (this *Receiver) getWebhookUrl(info Info) (string, error) {
+	if info.Webhook == "" {
+		if !isSet(this.config.SlackWebhookUrl) {
+			return "", errors.New("missing default slack webhook")
+		}
+		return this.config.SlackWebhookUrl, nil
+	}
+	webhook, ok := this.config.SlackWebhooks[info.Webhook]
+	if !ok {
+		return "", errors.New("unknown slack webhook " + info.Webhook)
+	}
+	return webhook, nil
 }
 
-func (this *Receiver) send(pl string) error {
-	b, err := json.Marshal(map[string]string{"text": pl})
+func (this *Receiver) send(webhook string, pl Payload) error {
+	b, err := json.Marshal(pl)
 	if err != nil {
 		return err
 	}
-	resp, err := http.Post(this.config.SlackWebhookUrl, "application/json", bytes.NewBuffer(b))
+	resp, err := this.client.Post(webhook, "application/json", bytes.NewBuffer(b))
 	if err != nil {
 		this.config.GetLogger().Error("unable to send slack message", "error", err)
 		return err
@@ -86,6 +220,108 @@ func (this *Receiver) send(pl string) error {
 	return nil
 }
 
+// postMessage uses https://api.slack.com/methods/chat.postMessage and returns the timestamp of the posted message
+func (this *Receiver) postMessage(pl Payload) (ts string, err error) {
+	b, err := json.Marshal(pl)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(this.config.SlackApiUrl, "/")+"/chat.postMessage", bytes.NewBuffer(b))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Authorization", "Bearer "+this.config.SlackBotToken)
+	resp, err := this.client.Do(req)
+	if err != nil {
+		this.config.GetLogger().Error("unable to send slack message", "error", err)
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode > 299 {
+		err = errors.New("unexpected status code " + strconv.Itoa(resp.StatusCode) + ": " + string(body))
+		this.config.GetLogger().Error("unable to send slack message", "error", err)
+		return "", err
+	}
+	result := ApiResponse{}
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return "", err
+	}
+	if !result.Ok {
+		err = errors.New("slack api error: " + result.Error)
+		this.config.GetLogger().Error("unable to send slack message", "error", err)
+		return "", err
+	}
+	return result.Ts, nil
+}
+
+func (this *Receiver) postThreaded(pl Payload, threadKey string) error {
+	storeKey := pl.Channel + "/" + threadKey
+	thread := Thread{}
+	found, err := this.threads.Get(storeKey, &thread)
+	if err != nil {
+		this.config.GetLogger().Warn("unable to read slack thread, post new message", "error", err)
+		found = false
+	}
+	if found && time.Since(thread.Created) < this.threadMaxAge {
+		pl.ThreadTs = thread.Ts
+		pl.ReplyBroadcast = this.config.SlackThreadBroadcast
+		_, err = this.postMessage(pl)
+		if err == nil || !strings.Contains(err.Error(), "thread_not_found") {
+			return err
+		}
+		this.config.GetLogger().Warn("slack thread not found, post new message", "channel", pl.Channel, "ts", thread.Ts)
+		pl.ThreadTs = ""
+		pl.ReplyBroadcast = false
+	}
+	ts, err := this.postMessage(pl)
+	if err != nil {
+		return err
+	}
+	err = this.threads.Set(storeKey, Thread{Channel: pl.Channel, Ts: ts, Created: time.Now()})
+	if err != nil {
+		this.config.GetLogger().Error("unable to store slack thread", "error", err)
+	}
+	return nil
+}
+
+// cleanupThreads removes threads, which are too old to be continued
+func (this *Receiver) cleanupThreads(now time.Time) {
+	keys, err := this.threads.Keys()
+	if err != nil {
+		this.config.GetLogger().Error("unable to list slack threads", "error", err)
+		return
+	}
+	for _, key := range keys {
+		thread := Thread{}
+		found, err := this.threads.Get(key, &thread)
+		if err != nil || !found || now.Sub(thread.Created) < this.threadMaxAge {
+			continue
+		}
+		err = this.threads.Delete(key)
+		if err != nil {
+			this.config.GetLogger().Error("unable to delete slack thread", "error", err)
+		}
+	}
+}
+
+// CreateMessage creates the text payload or, if config.SlackBlockKit is set, the block kit layout with the title as
+// fallback text for notifications
+func (this *Receiver) CreateMessage(message model.Message) (result Payload, err error) {
+	if this.config.SlackBlockKit {
+		result.Text = message.Title
+		if result.Text == "" {
+			result.Text = message.Sender
+		}
+		result.Blocks = CreateBlocks(message)
+		return result, nil
+	}
+	result.Text, err = this.CreatePayload(message)
+	return result, err
+}
+
 func (this *Receiver) CreatePayload(message model.Message) (result string, err error) {
 	str := strings.Builder{}
 	err = this.tmpl.Execute(&str, message)
@@ -49,7 +49,7 @@ func TestReceiver_send(t *testing.T) {
 		return
 	}
 
-	err = receiver.send("test-message")
+	err = receiver.send(server.URL, Payload{Text: "test-message"})
 	if err != nil {
 		t.Error(err)
 		return
@@ -64,6 +64,55 @@ func TestReceiver_send(t *testing.T) {
 
 }
 
+func TestReceiver_SendTargets(t *testing.T) {
+	receivedMessages := map[string][]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		msg, err := io.ReadAll(request.Body)
+		if err != nil {
+			t.Error(err)
+			http.Error(writer, err.Error(), 500)
+			return
+		}
+		receivedMessages[request.URL.Path] = append(receivedMessages[request.URL.Path], string(msg))
+		writer.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	receiver, err := New(context.Background(), &sync.WaitGroup{}, configuration.Config{
+		SlackWebhookUrl: server.URL + "/default",
+		SlackWebhooks:   map[string]string{"team-a": server.URL + "/team-a"},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, info := range []string{"", "team-a", `{"webhook": "team-a", "channel": "#team-a"}`, `{"channel": "#other"}`} {
+		err = receiver.ValidateAdditionalInfo(info)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		err = receiver.Send(model.Message{Title: "test"}, info)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	if len(receivedMessages["/default"]) != 2 || len(receivedMessages["/team-a"]) != 2 {
+		t.Errorf("%#v", receivedMessages)
+		return
+	}
+	if !strings.Contains(receivedMessages["/team-a"][1], `"channel":"#team-a"`) || !strings.Contains(receivedMessages["/default"][1], `"channel":"#other"`) {
+		t.Errorf("%#v", receivedMessages)
+	}
+
+	if receiver.ValidateAdditionalInfo("unknown") == nil {
+		t.Error("expected error for unknown webhook")
+	}
+}
+
 func TestReceiver_CreatePayload(t *testing.T) {
 	receiver, err := New(context.Background(), &sync.WaitGroup{}, configuration.Config{SlackWebhookUrl: "placeholder"})
 	if err != nil {
@@ -142,4 +191,21 @@ Stet clita kasd gubergren, no sea takimata sanctus est Lorem ipsum dolor sit ame
 
 	})
 
+	t.Run("labels", func(t *testing.T) {
+		pl, err := receiver.CreatePayload(model.Message{
+			Sender: sender,
+			Title:  title,
+			Body:   body,
+			Labels: map[string]string{"environment": "prod", "instance": "worker-1"},
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if !strings.Contains(pl, "Labels:  `environment=prod`  `instance=worker-1`") {
+			t.Error("missing labels in payload", pl)
+		}
+	})
+
 }
@@ -0,0 +1,167 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package teams
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/receiver/registry"
+)
+
+func init() {
+	registry.ReceiverFactories = append(registry.ReceiverFactories, func(ctx context.Context, wg *sync.WaitGroup, config configuration.Config) (name string, receiver registry.Receiver, err error) {
+		name = "teams"
+		receiver, err = New(ctx, wg, config)
+		return name, receiver, err
+	})
+}
+
+func New(ctx context.Context, wg *sync.WaitGroup, config configuration.Config) (*Receiver, error) {
+	if config.TeamsWebhookUrl == "" || config.TeamsWebhookUrl == "-" {
+		return nil, fmt.Errorf("%w (missing teams webhook)", registry.ErrNotConfigured)
+	}
+	return &Receiver{config: config, client: &http.Client{Timeout: 30 * time.Second}}, nil
+}
+
+type Receiver struct {
+	config configuration.Config
+	client *http.Client
+}
+
+func (this *Receiver) Send(message model.Message, additionalInfo string) error {
+	pl, err := this.CreatePayload(message)
+	if err != nil {
+		return err
+	}
+	return this.send(pl)
+}
+
+func (this *Receiver) send(pl []byte) error {
+	resp, err := this.client.Post(this.config.TeamsWebhookUrl, "application/json", bytes.NewBuffer(pl))
+	if err != nil {
+		this.config.GetLogger().Error("unable to send teams message", "error", err)
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode > 299 {
+		body, _ := io.ReadAll(resp.Body)
+		err = errors.New("unexpected status code " + strconv.Itoa(resp.StatusCode) + ": " + string(body))
+		this.config.GetLogger().Error("unable to send teams message", "error", err)
+		return err
+	}
+	return nil
+}
+
+// CreatePayload creates a message with a single adaptive card attachment
+// https://learn.microsoft.com/en-us/microsoftteams/platform/webhooks-and-connectors/how-to/connectors-using#send-adaptive-cards-using-an-incoming-webhook
+func (this *Receiver) CreatePayload(message model.Message) (result []byte, err error) {
+	facts := []Fact{{Title: "From", Value: message.Sender}}
+	if message.Severity != "" {
+		facts = append(facts, Fact{Title: "Severity", Value: string(message.Severity)})
+	}
+	if len(message.Tags) > 0 {
+		facts = append(facts, Fact{Title: "Tags", Value: strings.Join(message.Tags, ", ")})
+	}
+	for _, key := range slices.Sorted(maps.Keys(message.Labels)) {
+		facts = append(facts, Fact{Title: key, Value: message.Labels[key]})
+	}
+	if message.Repetition != nil {
+		facts = append(facts, Fact{Title: "Repeated", Value: message.Repetition.String()})
+	}
+	//text blocks require a text, so empty titles and bodies are skipped
+	body := []Element{}
+	if message.Title != "" {
+		body = append(body, Element{Type: "TextBlock", Text: message.Title, Size: "Large", Weight: "Bolder", Color: GetColor(message), Wrap: true})
+	}
+	body = append(body, Element{Type: "FactSet", Facts: facts})
+	if message.Body != "" {
+		body = append(body, Element{Type: "TextBlock", Text: message.Body, Wrap: true})
+	}
+	card := AdaptiveCard{
+		Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+		Type:    "AdaptiveCard",
+		Version: "1.4",
+		Body:    body,
+		MsTeams: map[string]string{"width": "Full"},
+	}
+	return json.Marshal(Payload{
+		Type:        "message",
+		Attachments: []Attachment{{ContentType: "application/vnd.microsoft.card.adaptive", Content: card}},
+	})
+}
+
+// GetColor maps the severity of the message to adaptive card colors
+func GetColor(message model.Message) string {
+	switch message.GetSeverity() {
+	case model.SeverityCritical, model.SeverityError:
+		return "Attention"
+	case model.SeverityWarning:
+		return "Warning"
+	case model.SeverityNotice:
+		return "Accent"
+	default:
+		return "Default"
+	}
+}
+
+type Payload struct {
+	Type        string       `json:"type"`
+	Attachments []Attachment `json:"attachments"`
+}
+
+type Attachment struct {
+	ContentType string       `json:"contentType"`
+	ContentUrl  *string      `json:"contentUrl"`
+	Content     AdaptiveCard `json:"content"`
+}
+
+type AdaptiveCard struct {
+	Schema  string            `json:"$schema"`
+	Type    string            `json:"type"`
+	Version string            `json:"version"`
+	Body    []Element         `json:"body"`
+	MsTeams map[string]string `json:"msteams,omitempty"`
+}
+
+type Element struct {
+	Type   string `json:"type"`
+	Text   string `json:"text,omitempty"`
+	Size   string `json:"size,omitempty"`
+	Weight string `json:"weight,omitempty"`
+	Color  string `json:"color,omitempty"`
+	Wrap   bool   `json:"wrap,omitempty"`
+	Facts  []Fact `json:"facts,omitempty"`
+}
+
+type Fact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
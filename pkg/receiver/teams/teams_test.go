@@ -0,0 +1,116 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package teams
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+func TestReceiver_Send(t *testing.T) {
+	receivedMessages := []Payload{}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		msg, err := io.ReadAll(request.Body)
+		if err != nil {
+			t.Error(err)
+			http.Error(writer, err.Error(), 500)
+			return
+		}
+		pl := Payload{}
+		err = json.Unmarshal(msg, &pl)
+		if err != nil {
+			t.Error(err)
+			http.Error(writer, err.Error(), 400)
+			return
+		}
+		receivedMessages = append(receivedMessages, pl)
+		writer.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	receiver, err := New(context.Background(), &sync.WaitGroup{}, configuration.Config{TeamsWebhookUrl: server.URL})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = receiver.Send(model.Message{
+		Sender: "github.com/SENERGY-Platform/developer-notifications",
+		Title:  "Test Message",
+		Body:   "test body",
+		Tags:   []string{model.KnownTags.Warning, "mail"},
+	}, "")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(receivedMessages) != 1 || len(receivedMessages[0].Attachments) != 1 {
+		t.Fatalf("%#v", receivedMessages)
+	}
+	attachment := receivedMessages[0].Attachments[0]
+	if attachment.ContentType != "application/vnd.microsoft.card.adaptive" || attachment.Content.Type != "AdaptiveCard" {
+		t.Errorf("%#v", attachment)
+	}
+	expected := []Element{
+		{Type: "TextBlock", Text: "Test Message", Size: "Large", Weight: "Bolder", Color: "Warning", Wrap: true},
+		{Type: "FactSet", Facts: []Fact{{Title: "From", Value: "github.com/SENERGY-Platform/developer-notifications"}, {Title: "Tags", Value: "warning, mail"}}},
+		{Type: "TextBlock", Text: "test body", Wrap: true},
+	}
+	if !reflect.DeepEqual(attachment.Content.Body, expected) {
+		t.Errorf("\n%#v\n%#v\n", expected, attachment.Content.Body)
+	}
+
+	t.Run("empty title and body", func(t *testing.T) {
+		pl, err := receiver.CreatePayload(model.Message{Sender: "sender"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := Payload{}
+		err = json.Unmarshal(pl, &result)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []Element{{Type: "FactSet", Facts: []Fact{{Title: "From", Value: "sender"}}}}
+		if !reflect.DeepEqual(result.Attachments[0].Content.Body, expected) {
+			t.Errorf("%#v", result.Attachments[0].Content.Body)
+		}
+	})
+}
+
+func TestGetColor(t *testing.T) {
+	tests := map[string][]string{
+		"Attention": {model.KnownTags.Warning, model.KnownTags.Error},
+		"Warning":   {model.KnownTags.Warning},
+		"Accent":    {model.KnownTags.Notification},
+		"Default":   nil,
+	}
+	for expected, tags := range tests {
+		if actual := GetColor(model.Message{Tags: tags}); actual != expected {
+			t.Error(tags, actual, expected)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/receiver/registry"
+)
+
+func init() {
+	registry.ReceiverFactories = append(registry.ReceiverFactories, func(ctx context.Context, wg *sync.WaitGroup, config configuration.Config) (name string, receiver registry.Receiver, err error) {
+		name = "webhook"
+		receiver, err = New(ctx, wg, config)
+		return name, receiver, err
+	})
+}
+
+// Info is the expected AdditionalReceiverInfo of webhook subscriptions.
+// Body is a text/template executed with the model.Message; if empty, the message is sent as json.
+// Credentials like the Authorization header must be referenced by SecretHeaders, so that they are not
+// stored with the subscription.
+type Info struct {
+	Url           string            `json:"url"`
+	Method        string            `json:"method"` //defaults to POST
+	Headers       map[string]string `json:"headers"`
+	SecretHeaders map[string]string `json:"secret_headers,omitempty"` //header name -> name of a value in config.WebhookSecrets
+	Body          string            `json:"body"`
+}
+
+var templateFunctions = template.FuncMap{
+	"json": func(value interface{}) (string, error) {
+		b, err := json.Marshal(value)
+		return string(b), err
+	},
+}
+
+func New(ctx context.Context, wg *sync.WaitGroup, config configuration.Config) (*Receiver, error) {
+	return &Receiver{config: config, client: &http.Client{Timeout: 30 * time.Second}}, nil
+}
+
+type Receiver struct {
+	config    configuration.Config
+	client    *http.Client
+	templates sync.Map //body template string -> *template.Template
+}
+
+func (this *Receiver) Send(message model.Message, additionalInfo string) error {
+	info, err := this.parseInfo(additionalInfo)
+	if err != nil {
+		return err
+	}
+	body, err := this.CreatePayload(info, message)
+	if err != nil {
+		return err
+	}
+	return this.send(info, body)
+}
+
+func (this *Receiver) ValidateAdditionalInfo(additionalInfo string) error {
+	info, err := this.parseInfo(additionalInfo)
+	if err != nil {
+		return err
+	}
+	_, err = this.getTemplate(info.Body)
+	return err
+}
+
+func (this *Receiver) parseInfo(additionalInfo string) (info Info, err error) {
+	err = json.Unmarshal([]byte(additionalInfo), &info)
+	if err != nil {
+		return info, errors.New("expect json object with url, method, headers and body: " + err.Error())
+	}
+	if info.Method == "" {
+		info.Method = http.MethodPost
+	}
+	u, err := url.Parse(info.Url)
+	if err != nil {
+		return info, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return info, errors.New("expect http or https url")
+	}
+	for name := range info.Headers {
+		if isSensitiveHeader(name) {
+			return info, errors.New("header " + name + " may contain credentials, use secret_headers to reference a value of webhook_secrets")
+		}
+	}
+	for name, secret := range info.SecretHeaders {
+		if _, ok := this.config.WebhookSecrets[secret]; !ok {
+			return info, errors.New("unknown webhook secret " + secret + " for header " + name)
+		}
+	}
+	return info, nil
+}
+
+// isSensitiveHeader returns true for headers, which are usually used for credentials
+func isSensitiveHeader(name string) bool {
+	name = strings.ToLower(name)
+	switch name {
+	case "authorization", "proxy-authorization", "cookie":
+		return true
+	}
+	for _, part := range []string{"token", "secret", "password", "api-key", "apikey"} {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+	return false
+}
+
+func (this *Receiver) getTemplate(body string) (*template.Template, error) {
+	if cached, ok := this.templates.Load(body); ok {
+		return cached.(*template.Template), nil
+	}
+	tmpl, err := template.New("webhook").Funcs(templateFunctions).Parse(body)
+	if err != nil {
+		return nil, err
+	}
+	this.templates.Store(body, tmpl)
+	return tmpl, nil
+}
+
+func (this *Receiver) CreatePayload(info Info, message model.Message) (result string, err error) {
+	if info.Body == "" {
+		b, err := json.Marshal(message)
+		return string(b), err
+	}
+	tmpl, err := this.getTemplate(info.Body)
+	if err != nil {
+		return "", err
+	}
+	str := strings.Builder{}
+	err = tmpl.Execute(&str, message)
+	if err != nil {
+		return "", err
+	}
+	return str.String(), nil
+}
+
+func (this *Receiver) send(info Info, body string) error {
+	req, err := http.NewRequest(info.Method, info.Url, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range info.Headers {
+		req.Header.Set(key, value)
+	}
+	for key, secret := range info.SecretHeaders {
+		req.Header.Set(key, this.config.WebhookSecrets[secret])
+	}
+	resp, err := this.client.Do(req)
+	if err != nil {
+		this.config.GetLogger().Error("unable to send webhook message", "error", err)
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(resp.Body)
+		err = errors.New("unexpected status code " + strconv.Itoa(resp.StatusCode) + ": " + string(respBody))
+		this.config.GetLogger().Error("unable to send webhook message", "error", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+func TestReceiver_Send(t *testing.T) {
+	type request struct {
+		Method string
+		Header http.Header
+		Body   string
+	}
+	received := []request{}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, request{Method: r.Method, Header: r.Header, Body: string(body)})
+		writer.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	receiver, err := New(context.Background(), &sync.WaitGroup{}, configuration.Config{
+		WebhookSecrets: map[string]string{"test-token": "Bearer secret"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := model.Message{Sender: "test", Title: `say "hello"`, Body: "body", Tags: []string{"error"}}
+
+	t.Run("template", func(t *testing.T) {
+		info, _ := json.Marshal(Info{
+			Url:           server.URL,
+			Method:        http.MethodPut,
+			Headers:       map[string]string{"X-Source": "test"},
+			SecretHeaders: map[string]string{"Authorization": "test-token"},
+			Body:          `{"text": {{json .Title}}, "tags": {{json .Tags}}}`,
+		})
+		err = receiver.ValidateAdditionalInfo(string(info))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = receiver.Send(message, string(info))
+		if err != nil {
+			t.Fatal(err)
+		}
+		last := received[len(received)-1]
+		if last.Method != http.MethodPut || last.Header.Get("Authorization") != "Bearer secret" || last.Header.Get("X-Source") != "test" {
+			t.Errorf("%#v", last)
+		}
+		if last.Body != `{"text": "say \"hello\"", "tags": ["error"]}` {
+			t.Error(last.Body)
+		}
+	})
+
+	t.Run("default body", func(t *testing.T) {
+		err = receiver.Send(message, `{"url": "`+server.URL+`"}`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		last := received[len(received)-1]
+		actual := model.Message{}
+		err = json.Unmarshal([]byte(last.Body), &actual)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if last.Method != http.MethodPost || actual.Title != message.Title {
+			t.Errorf("%#v", last)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, info := range []string{
+			"",
+			"foo@example.com",
+			`{"url": "ftp://example.com"}`,
+			`{"url": "http://example.com", "body": "{{.Title"}`,
+			`{"url": "http://example.com", "headers": {"Authorization": "Bearer secret"}}`,
+			`{"url": "http://example.com", "headers": {"X-Api-Key": "secret"}}`,
+			`{"url": "http://example.com", "secret_headers": {"Authorization": "unknown"}}`,
+		} {
+			if receiver.ValidateAdditionalInfo(info) == nil {
+				t.Error("expected error for", info)
+			}
+		}
+	})
+}
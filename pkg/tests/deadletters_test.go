@@ -0,0 +1,115 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/client"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+func TestDeadLetters(t *testing.T) {
+	failing := atomic.Bool{}
+	failing.Store(true)
+	delivered := atomic.Int64{}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if failing.Load() {
+			http.Error(writer, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		delivered.Add(1)
+		writer.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	url, stop := startApi(t, configuration.Config{
+		SlackWebhookUrl:        server.URL,
+		DeliveryQueue:          true,
+		DeliveryMaxAttempts:    2,
+		DeliveryBackoffInitial: "10ms",
+		Subscriptions:          []model.Subscription{{Key: "slack", Receiver: "slack", DistinctTimeWindow: "1h", AdditionalReceiverInfo: `{"channel": "#alerts"}`}},
+	})
+	defer stop()
+
+	c := client.New(url)
+	for _, title := range []string{"first", "second"} {
+		err := c.SendMessage(model.Message{Sender: "test", Title: title})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	list := []model.Delivery{}
+	t.Run("list", func(t *testing.T) {
+		if code := request(t, http.MethodGet, url+"/dead-letters", nil, &list); code != http.StatusOK {
+			t.Fatal(code)
+		}
+		if len(list) != 2 {
+			t.Fatal(len(list))
+		}
+		if list[0].Subscription.Key != "slack" || list[0].Subscription.Receiver != "slack" || list[0].Message.Title != "first" || len(list[0].Attempts) != 2 || list[0].Attempts[0].Error == "" || list[0].Subscription.AdditionalReceiverInfo != `{"channel":"#alerts"}` {
+			t.Errorf("%#v", list[0])
+		}
+	})
+
+	t.Run("inspect", func(t *testing.T) {
+		result := model.Delivery{}
+		if code := request(t, http.MethodGet, url+"/dead-letters/"+list[1].Id, nil, &result); code != http.StatusOK {
+			t.Fatal(code)
+		}
+		if result.Message.Title != "second" {
+			t.Errorf("%#v", result)
+		}
+		if code := request(t, http.MethodGet, url+"/dead-letters/unknown", nil, nil); code != http.StatusNotFound {
+			t.Error(code)
+		}
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		failing.Store(false)
+		if code := request(t, http.MethodPost, url+"/dead-letters/"+list[0].Id+"/replay", nil, nil); code != http.StatusOK {
+			t.Fatal(code)
+		}
+		time.Sleep(100 * time.Millisecond)
+		if delivered.Load() != 1 {
+			t.Error(delivered.Load())
+		}
+		if code := request(t, http.MethodGet, url+"/dead-letters/"+list[0].Id, nil, nil); code != http.StatusNotFound {
+			t.Error(code)
+		}
+	})
+
+	t.Run("purge", func(t *testing.T) {
+		if code := request(t, http.MethodDelete, url+"/dead-letters", nil, nil); code != http.StatusOK {
+			t.Fatal(code)
+		}
+		remaining := []model.Delivery{}
+		if code := request(t, http.MethodGet, url+"/dead-letters", nil, &remaining); code != http.StatusOK {
+			t.Fatal(code)
+		}
+		if len(remaining) != 0 {
+			t.Error(len(remaining))
+		}
+	})
+}
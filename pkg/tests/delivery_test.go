@@ -0,0 +1,154 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/broker"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+func TestDeliveryQueue(t *testing.T) {
+	dataDir := t.TempDir()
+
+	failing := atomic.Bool{}
+	failing.Store(true)
+	requests := atomic.Int64{}
+	delivered := atomic.Int64{}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests.Add(1)
+		if failing.Load() {
+			http.Error(writer, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		delivered.Add(1)
+		writer.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	config := configuration.Config{
+		SlackWebhookUrl:        server.URL,
+		DataDir:                dataDir,
+		DeliveryQueue:          true,
+		DeliveryMaxAttempts:    100,
+		DeliveryBackoffInitial: "10ms",
+		DeliveryBackoffMax:     "50ms",
+		Subscriptions:          []model.Subscription{{Key: "slack", Receiver: "slack", DistinctTimeWindow: "1h"}},
+	}
+
+	wg := &sync.WaitGroup{}
+	ctx, cancel := context.WithCancel(context.Background())
+	b, err := broker.New(ctx, wg, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = b.Message(model.Message{Sender: "test", Title: "queued"})
+	if err != nil {
+		t.Error(err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	if requests.Load() < 2 {
+		t.Error("expected retries", requests.Load())
+	}
+	if delivered.Load() != 0 {
+		t.Error(delivered.Load())
+	}
+
+	//restart with working receiver; the queued message must survive
+	cancel()
+	wg.Wait()
+	failing.Store(false)
+
+	wg = &sync.WaitGroup{}
+	defer wg.Wait()
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	_, err = broker.New(ctx, wg, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	if delivered.Load() != 1 {
+		t.Error(delivered.Load())
+	}
+}
+
+func TestDeliveryQueueOrder(t *testing.T) {
+	mux := sync.Mutex{}
+	requests := 0
+	titles := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		mux.Lock()
+		defer mux.Unlock()
+		requests++
+		if requests == 1 {
+			http.Error(writer, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		msg := model.Message{}
+		err := json.NewDecoder(request.Body).Decode(&msg)
+		if err != nil {
+			t.Error(err)
+		}
+		titles = append(titles, msg.Title)
+	}))
+	defer server.Close()
+
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	b, err := broker.New(ctx, wg, configuration.Config{
+		DeliveryQueue:          true,
+		DeliveryBackoffInitial: "50ms",
+		Subscriptions: []model.Subscription{{
+			Key:                    "webhook",
+			Receiver:               "webhook",
+			DistinctTimeWindow:     "1h",
+			AdditionalReceiverInfo: model.ReceiverInfo(`{"url": "` + server.URL + `"}`),
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, title := range []string{"1", "2", "3"} {
+		err = b.Message(model.Message{Sender: "test", Title: title})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	time.Sleep(300 * time.Millisecond)
+	mux.Lock()
+	defer mux.Unlock()
+	if !slices.Equal(titles, []string{"1", "2", "3"}) {
+		t.Error(titles)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/broker"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+func TestDigest(t *testing.T) {
+	url, received := startMessageRecorder(t)
+	info := model.ReceiverInfo(`{"url": "` + url + `"}`)
+	config := configuration.Config{
+		DataDir:          t.TempDir(),
+		DueCheckInterval: "50ms",
+		Subscriptions: []model.Subscription{
+			{Key: "digest", Receiver: "webhook", DeliveryMode: model.DeliveryModeDigest, DigestInterval: "500ms", AdditionalReceiverInfo: info},
+			{Key: "immediate", Receiver: "webhook", DistinctTimeWindow: "1h", AdditionalReceiverInfo: info, Filter: []model.MessageFilter{{Type: model.SenderFilter, Value: "other"}}},
+		},
+	}
+
+	wg := &sync.WaitGroup{}
+	ctx, cancel := context.WithCancel(context.Background())
+	b, err := broker.New(ctx, wg, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages := []model.Message{
+		{Sender: "a", Title: "disk full", Severity: model.SeverityWarning},
+		{Sender: "a", Title: "disk full", Severity: model.SeverityWarning},
+		{Sender: "a", Title: "disk full", Tags: []string{model.KnownTags.Error}},
+		{Sender: "b", Title: "restarted", Tags: []string{model.KnownTags.Notification}},
+		{Sender: "other", Title: "immediate"},
+	}
+	for _, msg := range messages {
+		err = b.Message(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if list := received(); len(list) != 1 || list[0].Title != "immediate" {
+		t.Fatalf("%#v", list)
+	}
+
+	//the buffer survives a restart
+	cancel()
+	wg.Wait()
+	wg = &sync.WaitGroup{}
+	defer wg.Wait()
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	_, err = broker.New(ctx, wg, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	if list := received(); len(list) != 1 {
+		t.Fatalf("digest sent before its interval %#v", list)
+	}
+	time.Sleep(500 * time.Millisecond)
+	list := received()
+	if len(list) != 2 {
+		t.Fatalf("%#v", list)
+	}
+	digest := list[1]
+	if digest.Sender != broker.DigestSender || !strings.HasPrefix(digest.Title, "Digest digest: 5 messages since ") || digest.Severity != model.SeverityError {
+		t.Errorf("%#v", digest)
+	}
+	lines := strings.Split(digest.Body, "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "3x [error] a: disk full (") || !strings.HasPrefix(lines[1], "1x [notice] b: restarted (") || !strings.HasPrefix(lines[2], "1x [info] other: immediate (") {
+		t.Error(digest.Body)
+	}
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/broker"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+func TestGetDistinctKey(t *testing.T) {
+	base := model.Message{
+		Sender: "test",
+		Title:  "connection lost",
+		Body:   "request 4711 failed at 2026-01-02T10:00:00Z",
+		Tags:   []string{model.KnownTags.Error},
+		Labels: map[string]string{"environment": "prod", "instance": "worker-1"},
+	}
+
+	tests := []struct {
+		name         string
+		subscription string
+		other        func(msg model.Message) model.Message
+		equal        bool
+	}{
+		{
+			name:         "whole message",
+			subscription: `{"key": "a"}`,
+			other:        func(msg model.Message) model.Message { return msg },
+			equal:        true,
+		},
+		{
+			name:         "whole message with different body",
+			subscription: `{"key": "a"}`,
+			other: func(msg model.Message) model.Message {
+				msg.Body = "request 4712 failed at 2026-01-02T10:05:00Z"
+				return msg
+			},
+			equal: false,
+		},
+		{
+			name:         "whole message with normalized body",
+			subscription: `{"key": "a", "distinct_body_replacements": [{"pattern": "\\d{4}-\\d{2}-\\d{2}T[\\d:]+Z", "replacement": "<time>"}, {"pattern": "request \\d+", "replacement": "request <id>"}]}`,
+			other: func(msg model.Message) model.Message {
+				msg.Body = "request 4712 failed at 2026-01-02T10:05:00Z"
+				return msg
+			},
+			equal: true,
+		},
+		{
+			name:         "sender and title",
+			subscription: `{"key": "a", "distinct_fields": ["sender", "title"]}`,
+			other: func(msg model.Message) model.Message {
+				msg.Body = "other"
+				msg.Tags = nil
+				msg.Labels = nil
+				return msg
+			},
+			equal: true,
+		},
+		{
+			name:         "sender and title with different title",
+			subscription: `{"key": "a", "distinct_fields": ["sender", "title"]}`,
+			other: func(msg model.Message) model.Message {
+				msg.Title = "connection restored"
+				return msg
+			},
+			equal: false,
+		},
+		{
+			name:         "single label",
+			subscription: `{"key": "a", "distinct_fields": ["title", "label:environment"]}`,
+			other: func(msg model.Message) model.Message {
+				msg.Labels = map[string]string{"environment": "prod", "instance": "worker-2"}
+				return msg
+			},
+			equal: true,
+		},
+		{
+			name:         "single label with different value",
+			subscription: `{"key": "a", "distinct_fields": ["title", "label:environment"]}`,
+			other: func(msg model.Message) model.Message {
+				msg.Labels = map[string]string{"environment": "dev", "instance": "worker-1"}
+				return msg
+			},
+			equal: false,
+		},
+		{
+			name:         "all labels",
+			subscription: `{"key": "a", "distinct_fields": ["labels"]}`,
+			other: func(msg model.Message) model.Message {
+				msg.Labels = map[string]string{"environment": "prod", "instance": "worker-2"}
+				return msg
+			},
+			equal: false,
+		},
+		{
+			name:         "severity from legacy tag",
+			subscription: `{"key": "a", "distinct_fields": ["severity"]}`,
+			other: func(msg model.Message) model.Message {
+				msg.Title = "other"
+				msg.Tags = nil
+				msg.Severity = model.SeverityError
+				return msg
+			},
+			equal: true,
+		},
+		{
+			name:         "field values are not concatenated",
+			subscription: `{"key": "a", "distinct_fields": ["sender", "title"]}`,
+			other: func(msg model.Message) model.Message {
+				msg.Sender = "testconnection"
+				msg.Title = " lost"
+				return msg
+			},
+			equal: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sub := model.Subscription{}
+			err := json.Unmarshal([]byte(test.subscription), &sub)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = sub.Prepare()
+			if err != nil {
+				t.Fatal(err)
+			}
+			equal := broker.GetDistinctKey(base, sub) == broker.GetDistinctKey(test.other(base), sub)
+			if equal != test.equal {
+				t.Error(equal, test.equal)
+			}
+		})
+	}
+
+	for _, invalid := range []string{
+		`{"key": "a", "distinct_fields": ["unknown"]}`,
+		`{"key": "a", "distinct_fields": ["label:"]}`,
+		`{"key": "a", "distinct_body_replacements": [{"pattern": "("}]}`,
+	} {
+		sub := model.Subscription{}
+		err := json.Unmarshal([]byte(invalid), &sub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sub.Prepare() == nil {
+			t.Error("expected error for", invalid)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/broker"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/distinct"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+func TestDistinctStore(t *testing.T) {
+	t.Run("memory", func(t *testing.T) {
+		testDistinctStore(t, distinct.NewMemory())
+	})
+	t.Run("file", func(t *testing.T) {
+		wg := &sync.WaitGroup{}
+		defer wg.Wait()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		store, err := distinct.NewFile(ctx, wg, t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		testDistinctStore(t, store)
+	})
+}
+
+func testDistinctStore(t *testing.T, store distinct.Store) {
+	isNew, repetition, err := store.Mark("a", 100*time.Millisecond)
+	if err != nil || !isNew || repetition != nil {
+		t.Fatal(isNew, repetition, err)
+	}
+	isNew, _, err = store.Mark("a", 100*time.Millisecond)
+	if err != nil || isNew {
+		t.Fatal(isNew, err)
+	}
+	isNew, _, err = store.Mark("b", time.Hour)
+	if err != nil || !isNew {
+		t.Fatal(isNew, err)
+	}
+
+	for _, title := range []string{"first", "second", "third"} {
+		err = store.Suppress("a", "sub-a", model.Message{Title: title})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = store.Suppress("b", "sub-b", model.Message{Title: "other"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+	isNew, repetition, err = store.Mark("a", 100*time.Millisecond)
+	if err != nil || !isNew {
+		t.Fatal("window has not expired", isNew, err)
+	}
+	if repetition == nil || repetition.Count != 3 || time.Since(repetition.Since) > time.Second {
+		t.Errorf("%#v", repetition)
+	}
+	time.Sleep(150 * time.Millisecond)
+	isNew, repetition, err = store.Mark("a", time.Hour)
+	if err != nil || !isNew || repetition != nil {
+		t.Error(isNew, repetition, err)
+	}
+
+	all, err := store.TakeAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 1 || all[0].SubscriptionKey != "sub-b" || all[0].Message.Title != "other" || all[0].Repetition.Count != 1 {
+		t.Errorf("%#v", all)
+	}
+	all, err = store.TakeAll()
+	if err != nil || len(all) != 0 {
+		t.Error(all, err)
+	}
+}
+
+func TestPersistentDistinctStore(t *testing.T) {
+	url, received := startMessageRecorder(t)
+	config := configuration.Config{
+		DataDir:       t.TempDir(),
+		DistinctStore: distinct.StoreFile,
+		Subscriptions: []model.Subscription{{Key: "persistent", Receiver: "webhook", DistinctTimeWindow: "1h", AdditionalReceiverInfo: model.ReceiverInfo(`{"url": "` + url + `"}`)}},
+	}
+
+	for range 2 {
+		wg := &sync.WaitGroup{}
+		ctx, cancel := context.WithCancel(context.Background())
+		b, err := broker.New(ctx, wg, config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = b.Message(model.Message{Sender: "test", Title: "persistent"})
+		if err != nil {
+			t.Error(err)
+		}
+		cancel()
+		wg.Wait()
+	}
+	if messages := received(); len(messages) != 1 {
+		t.Errorf("%#v", messages)
+	}
+
+	config.DataDir = ""
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, err := broker.New(ctx, &sync.WaitGroup{}, config)
+	if err == nil {
+		t.Error("expected error for file store without data_dir")
+	}
+}
+
+func TestZeroDistinctTimeWindow(t *testing.T) {
+	for _, store := range []string{distinct.StoreMemory, distinct.StoreFile} {
+		t.Run(store, func(t *testing.T) {
+			url, received := startMessageRecorder(t)
+			wg := &sync.WaitGroup{}
+			defer wg.Wait()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			b, err := broker.New(ctx, wg, configuration.Config{
+				DataDir:       t.TempDir(),
+				DistinctStore: store,
+				Subscriptions: []model.Subscription{{Key: "zero", Receiver: "webhook", DistinctTimeWindow: "0s", AdditionalReceiverInfo: model.ReceiverInfo(`{"url": "` + url + `"}`)}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for range 3 {
+				err = b.Message(model.Message{Sender: "test", Title: "repeated"})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			time.Sleep(100 * time.Millisecond)
+			if messages := received(); len(messages) != 3 {
+				t.Errorf("%#v", messages)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/broker"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+func TestGroups(t *testing.T) {
+	url, received := startMessageRecorder(t)
+	config := configuration.Config{
+		DueCheckInterval: "20ms",
+		Subscriptions: []model.Subscription{{
+			Key:                    "grouped",
+			Receiver:               "webhook",
+			GroupBy:                []string{"sender", "label:instance"},
+			GroupWait:              "200ms",
+			GroupInterval:          "600ms",
+			AdditionalReceiverInfo: model.ReceiverInfo(`{"url": "` + url + `"}`),
+		}},
+	}
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	b, err := broker.New(ctx, wg, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	send := func(sender string, title string, instance string, severity model.Severity) {
+		err := b.Message(model.Message{Sender: sender, Title: title, Severity: severity, Labels: map[string]string{"instance": instance}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	start := time.Now()
+	send("a", "disk full", "i1", model.SeverityWarning)
+	send("a", "disk full", "i1", model.SeverityWarning)
+	send("a", "service down", "i1", model.SeverityCritical)
+	send("b", "disk full", "i1", model.SeverityWarning)
+	send("a", "disk full", "i2", model.SeverityWarning)
+
+	time.Sleep(100 * time.Millisecond)
+	if list := received(); len(list) != 0 {
+		t.Fatalf("group wait not respected %#v", list)
+	}
+
+	time.Sleep(250 * time.Millisecond)
+	list := received()
+	if len(list) != 3 {
+		t.Fatalf("%#v", list)
+	}
+	index := slices.IndexFunc(list, func(msg model.Message) bool { return msg.Title == "3 messages for label:instance=i1, sender=a" })
+	if index < 0 {
+		t.Fatalf("%#v", list)
+	}
+	if group := list[index]; group.Sender != "a" || group.Severity != model.SeverityCritical || group.Labels["instance"] != "i1" ||
+		group.Body != "[warning] a: disk full\n[warning] a: disk full\n[critical] a: service down" {
+		t.Errorf("%#v", group)
+	}
+	//single messages are sent unchanged
+	if !slices.ContainsFunc(list, func(msg model.Message) bool { return msg.Sender == "b" && msg.Title == "disk full" }) ||
+		!slices.ContainsFunc(list, func(msg model.Message) bool { return msg.Sender == "a" && msg.Labels["instance"] == "i2" }) {
+		t.Errorf("%#v", list)
+	}
+
+	//later messages wait for the group interval
+	send("a", "disk full", "i1", model.SeverityWarning)
+	time.Sleep(time.Until(start.Add(700 * time.Millisecond)))
+	if list := received(); len(list) != 3 {
+		t.Fatalf("group interval not respected %#v", list)
+	}
+	time.Sleep(time.Until(start.Add(1000 * time.Millisecond)))
+	if list := received(); len(list) != 4 || list[3].Title != "disk full" || list[3].Labels["instance"] != "i1" {
+		t.Fatalf("%#v", list)
+	}
+}
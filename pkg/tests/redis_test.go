@@ -0,0 +1,299 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"maps"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/broker"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/distinct"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+// redisStub is an in-process stand-in for the subset of redis used by distinct.Redis
+type redisStub struct {
+	password string
+	mux      sync.Mutex
+	values   map[string]string
+	expires  map[string]time.Time
+}
+
+type redisEntry = struct {
+	value string
+	found bool
+}
+
+func startRedisStub(t *testing.T, password string) (addr string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+	stub := &redisStub{password: password, values: map[string]string{}, expires: map[string]time.Time{}}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go stub.serve(conn)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func (this *redisStub) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	authenticated := this.password == ""
+	var queued [][]string
+	for {
+		value, err := distinct.ReadRespValue(reader)
+		if err != nil {
+			return
+		}
+		args := []string{}
+		for _, arg := range value.([]any) {
+			args = append(args, arg.(string))
+		}
+		command := strings.ToUpper(args[0])
+		var reply string
+		switch {
+		case command == "AUTH":
+			authenticated = args[1] == this.password
+			reply = "+OK\r\n"
+			if !authenticated {
+				reply = "-WRONGPASS invalid password\r\n"
+			}
+		case !authenticated:
+			reply = "-NOAUTH Authentication required.\r\n"
+		case command == "MULTI":
+			queued = [][]string{}
+			reply = "+OK\r\n"
+		case command == "EXEC":
+			this.mux.Lock()
+			reply = "*" + strconv.Itoa(len(queued)) + "\r\n"
+			for _, args := range queued {
+				reply += this.execute(args)
+			}
+			this.mux.Unlock()
+			queued = nil
+		case queued != nil:
+			queued = append(queued, args)
+			reply = "+QUEUED\r\n"
+		default:
+			this.mux.Lock()
+			reply = this.execute(args)
+			this.mux.Unlock()
+		}
+		_, err = conn.Write([]byte(reply))
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (this *redisStub) get(key string) (string, bool) {
+	if expires, ok := this.expires[key]; ok && time.Now().After(expires) {
+		delete(this.values, key)
+		delete(this.expires, key)
+	}
+	value, ok := this.values[key]
+	return value, ok
+}
+
+func bulk(value string, found bool) string {
+	if !found {
+		return "$-1\r\n"
+	}
+	return "$" + strconv.Itoa(len(value)) + "\r\n" + value + "\r\n"
+}
+
+func (this *redisStub) execute(args []string) string {
+	switch strings.ToUpper(args[0]) {
+	case "SELECT", "PING":
+		return "+OK\r\n"
+	case "GET":
+		return bulk(this.get(args[1]))
+	case "SET":
+		_, exists := this.get(args[1])
+		expires := time.Time{}
+		keepTtl := false
+		for i := 3; i < len(args); i++ {
+			switch strings.ToUpper(args[i]) {
+			case "KEEPTTL":
+				keepTtl = true
+			case "NX":
+				if exists {
+					return "$-1\r\n"
+				}
+			case "PX":
+				ms, _ := strconv.Atoi(args[i+1])
+				expires = time.Now().Add(time.Duration(ms) * time.Millisecond)
+				i++
+			}
+		}
+		this.values[args[1]] = args[2]
+		if !keepTtl {
+			delete(this.expires, args[1])
+		}
+		if !expires.IsZero() {
+			this.expires[args[1]] = expires
+		}
+		return "+OK\r\n"
+	case "INCR", "DECRBY":
+		value, _ := this.get(args[1])
+		count, _ := strconv.Atoi(value)
+		delta := 1
+		if len(args) > 2 {
+			delta, _ = strconv.Atoi(args[2])
+			delta = -delta
+		}
+		this.values[args[1]] = strconv.Itoa(count + delta)
+		return ":" + strconv.Itoa(count+delta) + "\r\n"
+	case "PEXPIRE":
+		if _, ok := this.get(args[1]); !ok {
+			return ":0\r\n"
+		}
+		ms, _ := strconv.Atoi(args[2])
+		this.expires[args[1]] = time.Now().Add(time.Duration(ms) * time.Millisecond)
+		return ":1\r\n"
+	case "DEL":
+		count := 0
+		for _, key := range args[1:] {
+			if _, ok := this.get(key); ok {
+				count++
+			}
+			delete(this.values, key)
+			delete(this.expires, key)
+		}
+		return ":" + strconv.Itoa(count) + "\r\n"
+	case "SCAN":
+		//only prefix patterns are used; unlike path.Match, '*' also matches '/' in redis
+		cursor, _ := strconv.Atoi(args[1])
+		pattern, count := "*", 10
+		for i := 2; i+1 < len(args); i += 2 {
+			switch strings.ToUpper(args[i]) {
+			case "MATCH":
+				pattern = args[i+1]
+			case "COUNT":
+				count, _ = strconv.Atoi(args[i+1])
+			}
+		}
+		pattern = strings.TrimSuffix(pattern, "*")
+		prefix := strings.Builder{}
+		for i := 0; i < len(pattern); i++ {
+			if pattern[i] == '\\' && i+1 < len(pattern) {
+				i++
+			}
+			prefix.WriteByte(pattern[i])
+		}
+		//the stub pages through the sorted keys, the cursor is the index of the next key
+		all := slices.Sorted(maps.Keys(this.values))
+		end := min(cursor+count, len(all))
+		keys := []string{}
+		for _, key := range all[min(cursor, end):end] {
+			if _, ok := this.get(key); !ok {
+				continue
+			}
+			if strings.HasPrefix(key, prefix.String()) {
+				keys = append(keys, key)
+			}
+		}
+		next := end
+		if end >= len(all) {
+			next = 0
+		}
+		reply := "*2\r\n" + bulk(strconv.Itoa(next), true) + "*" + strconv.Itoa(len(keys)) + "\r\n"
+		for _, key := range keys {
+			reply += bulk(key, true)
+		}
+		return reply
+	default:
+		return fmt.Sprintf("-ERR unknown command '%v'\r\n", args[0])
+	}
+}
+
+func TestRedisDistinctStore(t *testing.T) {
+	addr := startRedisStub(t, "secret")
+
+	t.Run("store", func(t *testing.T) {
+		testDistinctStore(t, distinct.NewRedis(addr, "secret", 1, "test:"))
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		_, _, err := distinct.NewRedis(addr, "wrong", 0, "").Mark("a", time.Minute)
+		if err == nil || !strings.Contains(err.Error(), "WRONGPASS") {
+			t.Error(err)
+		}
+	})
+
+	t.Run("concurrent replicas", func(t *testing.T) {
+		url, received := startMessageRecorder(t)
+		config := configuration.Config{
+			DistinctStore:         distinct.StoreRedis,
+			DistinctRedisAddr:     addr,
+			DistinctRedisPassword: "secret",
+			Subscriptions:         []model.Subscription{{Key: "replicated", Receiver: "webhook", DistinctTimeWindow: "1h", AdditionalReceiverInfo: model.ReceiverInfo(`{"url": "` + url + `"}`)}},
+		}
+		wg := &sync.WaitGroup{}
+		defer wg.Wait()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		replicas := []*broker.Broker{}
+		for range 5 {
+			b, err := broker.New(ctx, wg, config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			replicas = append(replicas, b)
+		}
+		senders := sync.WaitGroup{}
+		for _, b := range replicas {
+			for range 4 {
+				senders.Go(func() {
+					err := b.Message(model.Message{Sender: "test", Title: "replicated"})
+					if err != nil {
+						t.Error(err)
+					}
+				})
+			}
+		}
+		senders.Wait()
+		if messages := received(); len(messages) != 1 {
+			t.Errorf("%#v", messages)
+		}
+
+		//all replicas see the suppressed duplicates of the others
+		replicas[0].SendSummaries()
+		messages := received()
+		if len(messages) != 2 || messages[1].Repetition == nil || messages[1].Repetition.Count != 19 {
+			t.Errorf("%#v", messages)
+		}
+	})
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/broker"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+func TestSubscriptionReload(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	writeFile := func(name string, content string) {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	getKeys := func(b *broker.Broker) (result []string) {
+		list, err := b.ListSubscriptions()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, sub := range list {
+			result = append(result, sub.Key)
+		}
+		return result
+	}
+
+	writeFile("a.json", `[{"key": "a", "receiver": "slack", "distinct_time_window": "1h"}]`)
+
+	b, err := broker.New(ctx, wg, configuration.Config{
+		SlackWebhookUrl:                 "placeholder",
+		SubscriptionFilesDir:            dir,
+		SubscriptionFilesReloadInterval: "100ms",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if keys := getKeys(b); !slices.Equal(keys, []string{"a"}) {
+		t.Error(keys)
+	}
+
+	t.Run("reload", func(t *testing.T) {
+		writeFile("b.json", `[{"key": "b", "receiver": "slack", "distinct_time_window": "1h"}]`)
+		err = b.ReloadSubscriptions()
+		if err != nil {
+			t.Error(err)
+		}
+		if keys := getKeys(b); !slices.Equal(keys, []string{"a", "b"}) {
+			t.Error(keys)
+		}
+	})
+
+	t.Run("invalid file keeps last valid set", func(t *testing.T) {
+		writeFile("c.json", `[{"key": "c", "receiver": "slack", "distinct_time_window": "1h"}`)
+		err = b.ReloadSubscriptions()
+		if err == nil {
+			t.Error("expected error")
+		}
+		if keys := getKeys(b); !slices.Equal(keys, []string{"a", "b"}) {
+			t.Error(keys)
+		}
+	})
+
+	t.Run("invalid duration keeps last valid set", func(t *testing.T) {
+		writeFile("c.json", `[{"key": "c", "receiver": "slack", "distinct_time_window": "foo"}]`)
+		err = b.ReloadSubscriptions()
+		if err == nil {
+			t.Error("expected error")
+		}
+		if keys := getKeys(b); !slices.Equal(keys, []string{"a", "b"}) {
+			t.Error(keys)
+		}
+	})
+
+	t.Run("polling", func(t *testing.T) {
+		writeFile("c.json", `[{"key": "c", "receiver": "slack", "distinct_time_window": "1h"}]`)
+		err = os.Remove(filepath.Join(dir, "a.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(500 * time.Millisecond)
+		if keys := getKeys(b); !slices.Equal(keys, []string{"b", "c"}) {
+			t.Error(keys)
+		}
+	})
+
+	t.Run("invalid receiver info keeps last valid set", func(t *testing.T) {
+		writeFile("b.json", `[{"key": "b", "receiver": "webhook", "distinct_time_window": "1h", "additional_receiver_info": "{\"url\": \"ftp://typo\"}"}]`)
+		err = b.ReloadSubscriptions()
+		if err == nil {
+			t.Error("expected error")
+		} else if !strings.Contains(err.Error(), "additional_receiver_info") {
+			t.Error(err)
+		}
+		if sub, err := b.GetSubscription("b"); err != nil || sub.Receiver != "slack" {
+			t.Error(err, sub)
+		}
+		writeFile("b.json", `[{"key": "b", "receiver": "slack", "distinct_time_window": "1h"}]`)
+	})
+
+	t.Run("file subscription shadows stored subscription", func(t *testing.T) {
+		err = b.CreateSubscription(model.Subscription{Key: "d", Receiver: "slack", DistinctTimeWindow: "1h"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		writeFile("d.json", `[{"key": "d", "receiver": "slack", "distinct_time_window": "2h"}]`)
+		err = b.ReloadSubscriptions()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if keys := getKeys(b); !slices.Equal(keys, []string{"b", "c", "d"}) {
+			t.Error(keys)
+		}
+		if sub, err := b.GetSubscription("d"); err != nil || !sub.ReadOnly || sub.DistinctTimeWindow != "2h" {
+			t.Error(err, sub)
+		}
+		//the shadowed copy can be deleted, the file subscription remains
+		err = b.DeleteSubscription("d")
+		if err != nil {
+			t.Error(err)
+		}
+		err = b.DeleteSubscription("d")
+		if !errors.Is(err, model.ErrReadOnly) {
+			t.Error(err)
+		}
+		if keys := getKeys(b); !slices.Equal(keys, []string{"b", "c", "d"}) {
+			t.Error(keys)
+		}
+	})
+}
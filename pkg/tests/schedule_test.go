@@ -0,0 +1,199 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/broker"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+func TestMaintenanceWindows(t *testing.T) {
+	dropUrl, dropped := startMessageRecorder(t)
+	deferUrl, deferred := startMessageRecorder(t)
+	windows := []model.MaintenanceWindow{{Start: time.Now().Add(-time.Minute), End: time.Now().Add(300 * time.Millisecond)}}
+	config := configuration.Config{
+		DataDir:          t.TempDir(),
+		DueCheckInterval: "20ms",
+		Subscriptions: []model.Subscription{
+			{
+				Key:                    "drop",
+				Receiver:               "webhook",
+				DistinctTimeWindow:     "1h",
+				MaintenanceWindows:     windows,
+				AdditionalReceiverInfo: model.ReceiverInfo(`{"url": "` + dropUrl + `"}`),
+			},
+			{
+				Key:                    "defer",
+				Receiver:               "webhook",
+				DistinctTimeWindow:     "1h",
+				MaintenanceWindows:     windows,
+				OutsideActiveHours:     model.OutsideActiveHoursDefer,
+				AdditionalReceiverInfo: model.ReceiverInfo(`{"url": "` + deferUrl + `"}`),
+			},
+		},
+	}
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	b, err := broker.New(ctx, wg, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, title := range []string{"first", "second", "first"} {
+		err = b.Message(model.Message{Sender: "test", Title: title})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if len(dropped()) != 0 || len(deferred()) != 0 {
+		t.Fatal("messages sent in maintenance window", dropped(), deferred())
+	}
+
+	time.Sleep(400 * time.Millisecond)
+	if list := dropped(); len(list) != 0 {
+		t.Errorf("%#v", list)
+	}
+	//deferred messages are released in order and pass the distinct time window of the subscription
+	list := deferred()
+	if len(list) != 2 || list[0].Title != "first" || list[1].Title != "second" {
+		t.Errorf("%#v", list)
+	}
+
+	err = b.Message(model.Message{Sender: "test", Title: "third"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if len(dropped()) != 1 || len(deferred()) != 3 {
+		t.Error(dropped(), deferred())
+	}
+}
+
+func TestDeferredMaxAge(t *testing.T) {
+	url, received := startMessageRecorder(t)
+	config := configuration.Config{
+		DueCheckInterval: "20ms",
+		DeferredMaxAge:   "100ms",
+		Subscriptions: []model.Subscription{{
+			Key:                    "defer",
+			Receiver:               "webhook",
+			DistinctTimeWindow:     "1h",
+			MaintenanceWindows:     []model.MaintenanceWindow{{Start: time.Now().Add(-time.Minute), End: time.Now().Add(300 * time.Millisecond)}},
+			OutsideActiveHours:     model.OutsideActiveHoursDefer,
+			AdditionalReceiverInfo: model.ReceiverInfo(`{"url": "` + url + `"}`),
+		}},
+	}
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	b, err := broker.New(ctx, wg, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = b.Message(model.Message{Sender: "test", Title: "expired"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//the message expires in the maintenance window and is not sent afterwards
+	time.Sleep(500 * time.Millisecond)
+	if list := received(); len(list) != 0 {
+		t.Errorf("%#v", list)
+	}
+
+	err = b.Message(model.Message{Sender: "test", Title: "active"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if list := received(); len(list) != 1 || list[0].Title != "active" {
+		t.Errorf("%#v", list)
+	}
+
+	t.Run("invalid max age", func(t *testing.T) {
+		_, err := broker.New(ctx, wg, configuration.Config{DeferredMaxAge: "a week"})
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+func TestDigestInMaintenanceWindow(t *testing.T) {
+	dropUrl, dropped := startMessageRecorder(t)
+	deferUrl, deferred := startMessageRecorder(t)
+	//the digests are due while the maintenance window is active
+	windows := []model.MaintenanceWindow{{Start: time.Now().Add(150 * time.Millisecond), End: time.Now().Add(600 * time.Millisecond)}}
+	config := configuration.Config{
+		DueCheckInterval: "20ms",
+		Subscriptions: []model.Subscription{
+			{
+				Key:                    "drop",
+				Receiver:               "webhook",
+				DeliveryMode:           model.DeliveryModeDigest,
+				DigestInterval:         "300ms",
+				MaintenanceWindows:     windows,
+				AdditionalReceiverInfo: model.ReceiverInfo(`{"url": "` + dropUrl + `"}`),
+			},
+			{
+				Key:                    "defer",
+				Receiver:               "webhook",
+				DeliveryMode:           model.DeliveryModeDigest,
+				DigestInterval:         "300ms",
+				MaintenanceWindows:     windows,
+				OutsideActiveHours:     model.OutsideActiveHoursDefer,
+				AdditionalReceiverInfo: model.ReceiverInfo(`{"url": "` + deferUrl + `"}`),
+			},
+		},
+	}
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	b, err := broker.New(ctx, wg, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = b.Message(model.Message{Sender: "test", Title: "disk full"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(500 * time.Millisecond)
+	if len(dropped()) != 0 || len(deferred()) != 0 {
+		t.Fatal("digest sent in maintenance window", dropped(), deferred())
+	}
+
+	//the deferred digest is sent once after the maintenance window and not added to a new digest
+	time.Sleep(700 * time.Millisecond)
+	if list := dropped(); len(list) != 0 {
+		t.Errorf("%#v", list)
+	}
+	if list := deferred(); len(list) != 1 || list[0].Sender != broker.DigestSender || !strings.Contains(list[0].Body, "test: disk full") {
+		t.Errorf("%#v", list)
+	}
+}
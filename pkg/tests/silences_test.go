@@ -0,0 +1,194 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/broker"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/client"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+func TestSilences(t *testing.T) {
+	recorderUrl, received := startMessageRecorder(t)
+	info := model.ReceiverInfo(`{"url": "` + recorderUrl + `"}`)
+	url, stop := startApi(t, configuration.Config{
+		DataDir: t.TempDir(),
+		Subscriptions: []model.Subscription{
+			{Key: "first", Receiver: "webhook", DistinctTimeWindow: "1ms", AdditionalReceiverInfo: info},
+			{Key: "second", Receiver: "webhook", DistinctTimeWindow: "1ms", AdditionalReceiverInfo: info},
+		},
+	})
+	defer stop()
+	c := client.New(url)
+	send := func(sender string, environment string) {
+		t.Helper()
+		err := c.SendMessage(model.Message{Sender: sender, Title: "disk full", Labels: map[string]string{"environment": environment}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, silence := range []model.Silence{
+			{Author: "test", End: time.Now().Add(time.Hour)},
+			{Author: "test", Matchers: []model.MessageFilter{{Type: model.SenderFilter, Value: "a"}}},
+			{Matchers: []model.MessageFilter{{Type: model.SenderFilter, Value: "a"}}, End: time.Now().Add(time.Hour)},
+			{Author: "test", Matchers: []model.MessageFilter{{Type: "unknown"}}, End: time.Now().Add(time.Hour)},
+			{Author: "test", Matchers: []model.MessageFilter{{Type: model.SenderFilter, Value: "a"}}, Start: time.Now().Add(time.Hour), End: time.Now()},
+		} {
+			if code := request(t, http.MethodPost, url+"/silences", silence, nil); code != http.StatusBadRequest {
+				t.Errorf("%v %#v", code, silence)
+			}
+		}
+	})
+
+	silence := model.Silence{
+		Matchers: []model.MessageFilter{
+			{Type: model.SenderFilter, Value: "a"},
+			{Type: model.LabelFilter, Key: "environment", Value: "prod"},
+		},
+		Subscriptions: []string{"first"},
+		End:           time.Now().Add(time.Hour),
+		Author:        "tester",
+		Comment:       "known incident",
+	}
+	t.Run("create", func(t *testing.T) {
+		if code := request(t, http.MethodPost, url+"/silences", silence, &silence); code != http.StatusOK {
+			t.Fatal(code)
+		}
+		if silence.Id == "" || silence.Start.IsZero() || silence.Created.IsZero() {
+			t.Fatalf("%#v", silence)
+		}
+		list := []model.Silence{}
+		if code := request(t, http.MethodGet, url+"/silences", nil, &list); code != http.StatusOK {
+			t.Fatal(code)
+		}
+		if len(list) != 1 || list[0].Id != silence.Id || list[0].Comment != "known incident" {
+			t.Errorf("%#v", list)
+		}
+	})
+
+	t.Run("silenced", func(t *testing.T) {
+		send("a", "prod")
+		send("a", "dev")
+		send("b", "prod")
+		time.Sleep(100 * time.Millisecond)
+		list := received()
+		if len(list) != 5 {
+			t.Fatalf("%#v", list)
+		}
+		prod := slices.DeleteFunc(list, func(msg model.Message) bool { return msg.Sender != "a" || msg.Labels["environment"] != "prod" })
+		if len(prod) != 1 {
+			t.Errorf("%#v", prod)
+		}
+		records := []model.SilencedMessage{}
+		if code := request(t, http.MethodGet, url+"/silenced-messages", nil, &records); code != http.StatusOK {
+			t.Fatal(code)
+		}
+		if len(records) != 1 || records[0].Message.Sender != "a" || !slices.Equal(records[0].Subscriptions, []string{"first"}) || !slices.Equal(records[0].Silences, []string{silence.Id}) {
+			t.Errorf("%#v", records)
+		}
+	})
+
+	t.Run("expire", func(t *testing.T) {
+		silence.End = time.Now()
+		if code := request(t, http.MethodPut, url+"/silences/"+silence.Id, silence, nil); code != http.StatusOK {
+			t.Fatal(code)
+		}
+		send("a", "prod")
+		time.Sleep(100 * time.Millisecond)
+		if list := received(); len(list) != 7 {
+			t.Errorf("%#v", list)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if code := request(t, http.MethodDelete, url+"/silences/"+silence.Id, nil, nil); code != http.StatusOK {
+			t.Fatal(code)
+		}
+		if code := request(t, http.MethodGet, url+"/silences/"+silence.Id, nil, nil); code != http.StatusNotFound {
+			t.Error(code)
+		}
+		if code := request(t, http.MethodDelete, url+"/silences/"+silence.Id, nil, nil); code != http.StatusNotFound {
+			t.Error(code)
+		}
+	})
+}
+
+func TestSilenceBufferedMessages(t *testing.T) {
+	digestUrl, digests := startMessageRecorder(t)
+	groupUrl, groups := startMessageRecorder(t)
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	b, err := broker.New(ctx, wg, configuration.Config{
+		DueCheckInterval: "20ms",
+		Subscriptions: []model.Subscription{
+			{Key: "digest", Receiver: "webhook", DeliveryMode: model.DeliveryModeDigest, DigestInterval: "300ms", AdditionalReceiverInfo: model.ReceiverInfo(`{"url": "` + digestUrl + `"}`)},
+			{Key: "group", Receiver: "webhook", GroupBy: []string{"sender"}, GroupWait: "300ms", GroupInterval: "1h", AdditionalReceiverInfo: model.ReceiverInfo(`{"url": "` + groupUrl + `"}`)},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, sender := range []string{"a", "b", "a"} {
+		err = b.Message(model.Message{Sender: sender, Title: "disk full"})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	//silences created after the messages were buffered mute them when the digest or group is sent
+	_, err = b.CreateSilence(model.Silence{
+		Matchers: []model.MessageFilter{{Type: model.SenderFilter, Value: "a"}},
+		End:      time.Now().Add(time.Hour),
+		Author:   "tester",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(500 * time.Millisecond)
+
+	if list := digests(); len(list) != 1 || !strings.Contains(list[0].Title, ": 1 messages since") || strings.Contains(list[0].Body, "a: disk full") {
+		t.Errorf("%#v", list)
+	}
+	if list := groups(); len(list) != 1 || list[0].Sender != "b" {
+		t.Errorf("%#v", list)
+	}
+	records, err := b.ListSilencedMessages()
+	if err != nil {
+		t.Fatal(err)
+	}
+	subscriptions := []string{}
+	for _, record := range records {
+		subscriptions = append(subscriptions, record.Subscriptions...)
+	}
+	slices.Sort(subscriptions)
+	if !slices.Equal(subscriptions, []string{"digest", "group", "group"}) {
+		t.Error(subscriptions)
+	}
+}
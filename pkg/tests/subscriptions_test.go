@@ -0,0 +1,201 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/client"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+func TestSubscriptionApi(t *testing.T) {
+	dataDir := t.TempDir()
+
+	receivedMessages := []string{}
+	mux := sync.Mutex{}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		msg, _ := io.ReadAll(request.Body)
+		mux.Lock()
+		defer mux.Unlock()
+		receivedMessages = append(receivedMessages, string(msg))
+		writer.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	start := func(t *testing.T) (url string, stop func()) {
+		config, err := configuration.Load("../../config.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		config.SlackWebhookUrl = server.URL
+		config.DataDir = dataDir
+		config.Subscriptions = []model.Subscription{{Key: "static", Receiver: "slack", DistinctTimeWindow: "1h", Filter: []model.MessageFilter{{Type: model.SenderFilter, Value: "static"}}}}
+		return startApi(t, config)
+	}
+
+	url, stop := start(t)
+
+	sub := model.Subscription{
+		Key:                "api",
+		Receiver:           "slack",
+		DistinctTimeWindow: "1h",
+		Filter:             []model.MessageFilter{{Type: model.SenderFilter, Value: "api"}},
+	}
+
+	t.Run("create", func(t *testing.T) {
+		if code := request(t, http.MethodPost, url+"/subscriptions", sub, nil); code != http.StatusOK {
+			t.Error(code)
+		}
+		if code := request(t, http.MethodPost, url+"/subscriptions", sub, nil); code != http.StatusConflict {
+			t.Error(code)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		invalid := sub
+		invalid.Key = "invalid"
+		invalid.Receiver = "unknown"
+		if code := request(t, http.MethodPost, url+"/subscriptions", invalid, nil); code != http.StatusBadRequest {
+			t.Error(code)
+		}
+	})
+
+	t.Run("static is read only", func(t *testing.T) {
+		if code := request(t, http.MethodDelete, url+"/subscriptions/static", nil, nil); code != http.StatusForbidden {
+			t.Error(code)
+		}
+		list := []model.Subscription{}
+		if code := request(t, http.MethodGet, url+"/subscriptions", nil, &list); code != http.StatusOK {
+			t.Error(code)
+		}
+		if len(list) != 2 || list[0].Key != "static" || !list[0].ReadOnly || list[1].Key != "api" || list[1].ReadOnly {
+			t.Errorf("%#v", list)
+		}
+	})
+
+	t.Run("message", func(t *testing.T) {
+		err := client.New(url).SendMessage(model.Message{Sender: "api", Title: "api"})
+		if err != nil {
+			t.Error(err)
+		}
+		mux.Lock()
+		defer mux.Unlock()
+		if len(receivedMessages) != 1 {
+			t.Error(len(receivedMessages))
+		}
+	})
+
+	stop()
+	url, stop = start(t)
+	defer stop()
+
+	t.Run("persisted", func(t *testing.T) {
+		result := model.Subscription{}
+		if code := request(t, http.MethodGet, url+"/subscriptions/api", nil, &result); code != http.StatusOK {
+			t.Error(code)
+		}
+		if result.Key != "api" || result.DistinctTimeWindow != "1h" {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		update := sub
+		update.Filter = []model.MessageFilter{{Type: model.SenderFilter, Value: "api-2"}}
+		if code := request(t, http.MethodPut, url+"/subscriptions/api", update, nil); code != http.StatusOK {
+			t.Error(code)
+		}
+		err := client.New(url).SendMessage(model.Message{Sender: "api-2", Title: "api"})
+		if err != nil {
+			t.Error(err)
+		}
+		mux.Lock()
+		defer mux.Unlock()
+		if len(receivedMessages) != 2 {
+			t.Error(len(receivedMessages))
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if code := request(t, http.MethodDelete, url+"/subscriptions/api", nil, nil); code != http.StatusOK {
+			t.Error(code)
+		}
+		if code := request(t, http.MethodGet, url+"/subscriptions/api", nil, nil); code != http.StatusNotFound {
+			t.Error(code)
+		}
+	})
+}
+
+func startApi(t *testing.T, config configuration.Config) (url string, stop func()) {
+	t.Helper()
+	wg := &sync.WaitGroup{}
+	ctx, cancel := context.WithCancel(context.Background())
+	port, err := GetFreePort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.ApiPort = strconv.Itoa(port)
+	err = pkg.Start(ctx, wg, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Second)
+	return "http://localhost:" + config.ApiPort, func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+func request(t *testing.T, method string, url string, body interface{}, result interface{}) (code int) {
+	t.Helper()
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if result != nil && resp.StatusCode == http.StatusOK {
+		err = json.NewDecoder(resp.Body).Decode(result)
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	return resp.StatusCode
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/developer-notifications/pkg/broker"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/configuration"
+	"github.com/SENERGY-Platform/developer-notifications/pkg/model"
+)
+
+// startMessageRecorder starts a server for webhook subscriptions, which records the received messages
+func startMessageRecorder(t *testing.T) (url string, received func() []model.Message) {
+	mux := sync.Mutex{}
+	messages := []model.Message{}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		msg := model.Message{}
+		err := json.NewDecoder(request.Body).Decode(&msg)
+		if err != nil {
+			t.Error(err)
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		mux.Lock()
+		messages = append(messages, msg)
+		mux.Unlock()
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, func() []model.Message {
+		mux.Lock()
+		defer mux.Unlock()
+		return append([]model.Message{}, messages...)
+	}
+}
+
+func TestSuppressedDuplicates(t *testing.T) {
+	url, received := startMessageRecorder(t)
+	info := model.ReceiverInfo(`{"url": "` + url + `"}`)
+
+	t.Run("count added to the next message", func(t *testing.T) {
+		wg := &sync.WaitGroup{}
+		defer wg.Wait()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		b, err := broker.New(ctx, wg, configuration.Config{
+			Subscriptions: []model.Subscription{{Key: "window", Receiver: "webhook", DistinctTimeWindow: "200ms", AdditionalReceiverInfo: info}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		start := len(received())
+		for range 5 {
+			err = b.Message(model.Message{Sender: "test", Title: "window"})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		time.Sleep(300 * time.Millisecond)
+		err = b.Message(model.Message{Sender: "test", Title: "window"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = b.Message(model.Message{Sender: "test", Title: "window"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		messages := received()[start:]
+		if len(messages) != 2 {
+			t.Fatalf("%#v", messages)
+		}
+		if messages[0].Repetition != nil {
+			t.Errorf("%#v", messages[0].Repetition)
+		}
+		if messages[1].Repetition == nil || messages[1].Repetition.Count != 4 {
+			t.Errorf("%#v", messages[1].Repetition)
+		}
+		if summary := messages[1].Repetition.String(); !strings.HasPrefix(summary, "repeated 4 times since ") {
+			t.Error(summary)
+		}
+	})
+
+	t.Run("summary interval", func(t *testing.T) {
+		wg := &sync.WaitGroup{}
+		defer wg.Wait()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		b, err := broker.New(ctx, wg, configuration.Config{
+			DistinctSummaryInterval: "200ms",
+			Subscriptions:           []model.Subscription{{Key: "summary", Receiver: "webhook", DistinctTimeWindow: "1h", AdditionalReceiverInfo: info}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		start := len(received())
+		for i := range 3 {
+			err = b.Message(model.Message{Sender: "test", Title: "summary", Body: "body " + strings.Repeat("!", i)})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		err = b.Message(model.Message{Sender: "test", Title: "summary", Body: "body "})
+		if err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(300 * time.Millisecond)
+		messages := received()[start:]
+		if len(messages) != 4 {
+			t.Fatalf("%#v", messages)
+		}
+		if messages[3].Repetition == nil || messages[3].Repetition.Count != 1 || messages[3].Body != "body " {
+			t.Errorf("%#v", messages[3])
+		}
+
+		//counts are reset by the summary
+		time.Sleep(300 * time.Millisecond)
+		if len(received()[start:]) != 4 {
+			t.Errorf("%#v", received()[start:])
+		}
+	})
+}